@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/slshen/paperscore/pkg/dataframe"
 	"github.com/slshen/paperscore/pkg/game"
 	"github.com/slshen/paperscore/pkg/stats"
 	"github.com/spf13/cobra"
@@ -15,6 +16,9 @@ func altCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "alt",
 		Short: "Display the cost of errors/misplays/good plays",
+		Long: `Display the cost of errors/misplays/good plays.  RCost is the change in
+run expectancy of the alternative play.  Replay is the difference in runs
+when the rest of the half inning is replayed with the alternative play.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			re, err := re.getRunExpectancy()
 			if err != nil {
@@ -27,6 +31,7 @@ func altCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			var tables []*dataframe.Data
 			for _, g := range games {
 				gs := stats.NewGameStats(re)
 				if err := gs.Read(g); err != nil {
@@ -35,13 +40,13 @@ func altCommand() *cobra.Command {
 				alt := gs.GetAltData()
 				alt.Name = fmt.Sprintf("%s game %s %s at %s Alt Plays", g.Date, g.Number, g.Visitor.Name, g.Home.Name)
 				alt.RemoveColumn("Game")
-				fmt.Println(alt)
+				tables = append(tables, alt)
 				pp := gs.GetPerPlayerAltData()
 				if pp.RowCount() > 0 {
-					fmt.Println(pp)
+					tables = append(tables, pp)
 				}
 			}
-			return nil
+			return render(cmd, tables...)
 		},
 	}
 	re.registerFlags(c.Flags())
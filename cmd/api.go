@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/slshen/paperscore/pkg/web"
+	"github.com/spf13/cobra"
+)
+
+func apiCommand() *cobra.Command {
+	var (
+		addr    string
+		dataDir string
+		re      reArgs
+	)
+	c := &cobra.Command{
+		Use:   "api",
+		Short: "Serve a read-only JSON API over games, states and stats",
+		Long: `Serve the read-only JSON API of "paperscore web" over the games in a data
+directory, without the app and without saving games.
+
+  GET /api/games                 games, filtered by team, tournament, from and to
+  GET /api/game?path=            the text of a game
+  GET /api/states?path=          the states of a game
+  GET /api/box?path=             the box score of a game
+  GET /api/plays?path=           the plays of a game
+  GET /api/stats?type=           batting or pitching stats of the filtered games
+  GET /api/re                    the RE matrix, observed from the filtered games
+                                 unless --re-matrix or --re-games is given
+  GET /api/tournaments           tournaments of the filtered games
+  GET /api/tournaments/report    the report for tournament name for team us
+
+Paths are relative to the data directory, e.g. 2022/20220521-1.gm.  The
+multi-game endpoints take a dir to query only the games under it.  Dates for
+from and to are YYYY-MM-DD.  Responses have an ETag that changes when any
+file in the data directory changes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dataDir == "" {
+				return fmt.Errorf("--data is required")
+			}
+			re, err := re.getRunExpectancy()
+			if err != nil {
+				return err
+			}
+			log.Printf("Serving %s at http://%s", dataDir, addr)
+			s := web.NewServer(dataDir, re)
+			s.ReadOnly = true
+			return http.ListenAndServe(addr, s)
+		},
+	}
+	c.Flags().StringVar(&addr, "addr", "localhost:8081", "Listen on `address`")
+	c.Flags().StringVar(&dataDir, "data", "", "Serve the games in `dir`")
+	re.registerFlags(c.Flags())
+	return c
+}
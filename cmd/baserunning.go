@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+	"github.com/spf13/cobra"
+)
+
+func baserunningCommand() *cobra.Command {
+	var (
+		re reArgs
+	)
+	c := &cobra.Command{
+		Use:   "baserunning",
+		Short: "Print baserunning stats per player and per team",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games, err := game.ReadGameFiles(args)
+			if err != nil {
+				return err
+			}
+			runExpectancy, err := re.getRunExpectancy()
+			if err != nil {
+				return err
+			}
+			gs := stats.NewGameStats(runExpectancy)
+			for _, g := range games {
+				if err := gs.Read(g); err != nil {
+					return err
+				}
+			}
+			players := gs.GetBaserunningData()
+			teams := gs.GetTeamBaserunningData()
+			return render(cmd,
+				players.Select(baserunningColumns("Name")...),
+				teams.Select(baserunningColumns()...),
+			)
+		},
+	}
+	re.registerFlags(c.Flags())
+	return c
+}
+
+func baserunningColumns(first ...string) []dataframe.Selection {
+	var cols []dataframe.Selection
+	for _, name := range first {
+		cols = append(cols, dataframe.Col(name))
+	}
+	return append(cols,
+		dataframe.Col("Team"),
+		dataframe.Rename("XBTOpp", "XBTO"),
+		dataframe.Col("XBT"),
+		dataframe.Rename("XBTPct", "XBT%"),
+		dataframe.Rename("FirstToThirdOpp", "13O"),
+		dataframe.Rename("FirstToThird", "13"),
+		dataframe.Rename("SecondToHomeOpp", "2HO"),
+		dataframe.Rename("SecondToHome", "2H"),
+		dataframe.Rename("FirstToHomeOpp", "1HO"),
+		dataframe.Rename("FirstToHome", "1H"),
+		dataframe.Rename("OutsOnBases", "OOB"),
+		dataframe.Rename("WPAdvances", "WPA"),
+		dataframe.Rename("PBAdvances", "PBA"),
+		dataframe.Rename("THAdvances", "THA"),
+		dataframe.Rename("StolenBases", "SB"),
+		dataframe.Rename("CaughtStealing", "CS"),
+		dataframe.Rename("PickedOff", "PO"),
+		dataframe.Rename("BRRuns", "BRR"),
+	)
+}
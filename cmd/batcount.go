@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/slshen/paperscore/pkg/dataframe"
 	"github.com/slshen/paperscore/pkg/game"
 	"github.com/slshen/paperscore/pkg/stats"
 	"github.com/spf13/cobra"
@@ -10,9 +9,11 @@ import (
 
 func battingCountCommand() *cobra.Command {
 	var (
-		us     string
-		notus  string
-		direct bool
+		us         string
+		notus      string
+		direct     bool
+		discipline bool
+		re         reArgs
 	)
 	c := &cobra.Command{
 		Use:   "batting-count",
@@ -22,19 +23,33 @@ func battingCountCommand() *cobra.Command {
 			bc.Us = us
 			bc.NotUs = notus
 			bc.Direct = direct
+			var err error
+			bc.RE, err = re.getRunExpectancy()
+			if err != nil {
+				return err
+			}
+			d := stats.NewDiscipline()
+			d.Us = us
+			d.NotUs = notus
 			gs, err := game.ReadGames(args)
 			if err != nil {
 				return err
 			}
 			for _, gm := range gs {
 				bc.Read(gm)
+				d.Read(gm)
+			}
+			tables := []*dataframe.Data{bc.GetData()}
+			if discipline {
+				tables = append(tables, d.GetBatterData(), d.GetPitcherData())
 			}
-			fmt.Println(bc.GetData())
-			return nil
+			return render(cmd, tables...)
 		},
 	}
 	c.Flags().StringVar(&us, "us", "", "Limit at bats to team ID's that contain `us`")
 	c.Flags().StringVar(&notus, "not-us", "", "Limit at bats to team ID's that do not contain `us`")
+	re.registerFlags(c.Flags())
+	c.Flags().BoolVar(&discipline, "discipline", false, "Also display the swing and take rates for each batter and pitcher")
 	c.Flags().BoolVar(&direct, "direct", false, "Display stats for the play directly after the last count, instead of for plays passing through the counts")
 	return c
 }
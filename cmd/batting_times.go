@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/slshen/paperscore/pkg/dataframe"
@@ -29,15 +28,14 @@ func battingTimesSeenPitcherCommand() *cobra.Command {
 			}
 			switch {
 			case team:
-				fmt.Println(b.GetTeamData())
+				return render(cmd, b.GetTeamData())
 			case us != "":
 				usdat := b.GetUsBatterData(us)
 				removeBattingColumns(usdat, include)
-				fmt.Println(usdat)
+				return render(cmd, usdat)
 			default:
-				fmt.Println(b.GetBatterData())
+				return render(cmd, b.GetBatterData())
 			}
-			return nil
 		},
 	}
 	flags := c.Flags()
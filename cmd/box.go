@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/slshen/paperscore/pkg/boxscore"
+	"github.com/slshen/paperscore/pkg/dataframe"
 	"github.com/slshen/paperscore/pkg/game"
 	"github.com/slshen/paperscore/pkg/stats"
 	"github.com/spf13/cobra"
@@ -36,11 +37,7 @@ func (b *boxCmd) writeBox(g *game.Game, firstGame bool) error {
 	}
 	var out io.Writer
 	if b.outputDir != "" {
-		base := filepath.Base(g.File.Path)
-		dot := strings.LastIndexByte(base, '.')
-		if dot > 1 {
-			base = base[:dot]
-		}
+		base := baseName(g.File.Path)
 		path := filepath.Join(b.outputDir, fmt.Sprintf("%s.pdf", base))
 		f, err := os.Create(path)
 		if err != nil {
@@ -91,6 +88,48 @@ func (b *boxCmd) writeBox(g *game.Game, firstGame bool) error {
 	return nil
 }
 
+// renderBoxes writes the tables of each box score in format, to a file
+// per game with --outdir or else all together to stdout.
+func (b *boxCmd) renderBoxes(games []*game.Game, format dataframe.Format) error {
+	re, err := b.reArgs.getRunExpectancy()
+	if err != nil {
+		return err
+	}
+	var tables []*dataframe.Data
+	for _, g := range games {
+		box, err := boxscore.NewBoxScore(g, re)
+		if err != nil {
+			return err
+		}
+		gameTables := box.Tables()
+		if b.outputDir != "" {
+			base := baseName(g.File.Path)
+			f, err := os.Create(filepath.Join(b.outputDir, base+format.Extension()))
+			if err != nil {
+				return err
+			}
+			err = dataframe.Render(f, format, gameTables...)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if len(games) > 1 {
+			for _, dat := range gameTables {
+				dat.Name = fmt.Sprintf("%s %s", g.ID, dat.Name)
+			}
+		}
+		tables = append(tables, gameTables...)
+	}
+	if b.outputDir != "" {
+		return nil
+	}
+	return dataframe.Render(os.Stdout, format, tables...)
+}
+
 func (b *boxCmd) init() *cobra.Command {
 	b.Command = &cobra.Command{
 		Use:   "box",
@@ -100,6 +139,16 @@ func (b *boxCmd) init() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			format, err := getFormat(cmd)
+			if err != nil {
+				return err
+			}
+			if format != dataframe.TextFormat {
+				if b.yamlFormat || b.pdfFormat {
+					return fmt.Errorf("--format %s cannot be used with --yaml or --pdf", format)
+				}
+				return b.renderBoxes(games, format)
+			}
 			for i, g := range games {
 				if err := b.writeBox(g, i == 0); err != nil {
 					return err
@@ -109,7 +158,7 @@ func (b *boxCmd) init() *cobra.Command {
 		},
 	}
 	flags := b.Flags()
-	flags.BoolVar(&b.yamlFormat, "yaml", false, "")
+	flags.BoolVar(&b.yamlFormat, "yaml", false, "Print the box score data in YAML format")
 	flags.BoolVar(&b.pdfFormat, "pdf", false, "Run paps to convert output to pdf")
 	flags.BoolVar(&b.scoringPlays, "scoring", false, "Include scoring plays in box")
 	flags.BoolVar(&b.plays, "plays", false, "Include play by play in box")
@@ -122,3 +171,9 @@ func boxCommand() *cobra.Command {
 	var b boxCmd
 	return b.init()
 }
+
+// baseName returns the name of the file at path without its extension.
+func baseName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
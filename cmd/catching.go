@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+	"github.com/spf13/cobra"
+)
+
+func catchingCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "catching-stats",
+		Aliases: []string{"catching"},
+		Short:   "Print catcher stats and pitcher-catcher battery splits",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games, err := game.ReadGameFiles(args)
+			if err != nil {
+				return err
+			}
+			gs := stats.NewGameStats(nil)
+			for _, g := range games {
+				if err := gs.Read(g); err != nil {
+					return err
+				}
+			}
+			catchers := gs.GetCatchingData()
+			batteries := gs.GetBatteryData()
+			return render(cmd, catchers.Select(
+				dataframe.Col("Name"),
+				dataframe.Col("Team"),
+				dataframe.Rename("Games", "G"),
+				dataframe.Rename("Pitches", "PC"),
+				dataframe.Rename("StolenBases", "SB"),
+				dataframe.Rename("CaughtStealing", "CS"),
+				dataframe.Rename("CSPct", "CS%"),
+				dataframe.Rename("PassedBalls", "PB"),
+				dataframe.Rename("WildPitches", "WP"),
+				dataframe.Rename("PickedOff", "PO"),
+			), batteries.Select(
+				dataframe.Col("Team"),
+				dataframe.Col("Pitcher"),
+				dataframe.Col("Catcher"),
+				dataframe.Rename("Pitches", "PC"),
+				dataframe.Rename("StealAttempts", "SBA"),
+				dataframe.Rename("StolenBases", "SB"),
+				dataframe.Rename("CaughtStealing", "CS"),
+				dataframe.Rename("PickedOff", "PO"),
+				dataframe.Rename("WildPitches", "WP"),
+				dataframe.Rename("PassedBalls", "PB"),
+				dataframe.Rename("WPRate", "WP%"),
+				dataframe.Rename("PBRate", "PB%"),
+			))
+		},
+	}
+	return c
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// flagConfigKeys are the config keys for flags whose key isn't the flag
+// name with _ for -
+var flagConfigKeys = map[string]string{
+	"rest":        "rest_rules",
+	"working-dir": "",
+	"profile":     "",
+	"help":        "",
+}
+
+func getFlagConfigKey(name string) string {
+	if key, ok := flagConfigKeys[name]; ok {
+		return key
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// applyConfig sets the flags of cmd that weren't given on the command
+// line from the config, logging where each came from.
+func applyConfig(cmd *cobra.Command, cfg *config.Config) error {
+	var err error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		key := getFlagConfigKey(f.Name)
+		if err != nil || f.Changed || key == "" {
+			return
+		}
+		val := cfg.GetString(key)
+		if val == "" {
+			return
+		}
+		if err = cmd.Flags().Set(f.Name, val); err != nil {
+			err = fmt.Errorf("cannot use %s %s from %s - %w", key, val, cfg.GetSource(key), err)
+			return
+		}
+		log.Default().Printf("Using --%s %s from %s", f.Name, val, cfg.GetSource(key))
+	})
+	return err
+}
+
+func configCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "config [dir]",
+		Short: "Show the effective settings and where each came from",
+		Long: `Show the effective settings and where each came from.
+
+Settings come from ~/.softball/config.yaml, then the first paperscore.yaml
+found in the data directory or one of its parents, then the profile
+selected by --profile, $SOFTBALL_PROFILE, or the profile setting in
+paperscore.yaml, then SOFTBALL_* environment variables, e.g.
+SOFTBALL_RE_MATRIX.  A flag given on the command line overrides them all.
+
+A setting is the default for any command flag of the same name with _
+for -, e.g.
+
+  re_matrix: data/tweaked_re.csv
+  us: pride
+  innings: 7
+  rest_rules: 36:1,51:2,66:3
+  format: markdown
+  profiles:
+    16u-2024:
+      us: pride-16u`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.GetConfig()
+			if cfg.ProjectPath != "" {
+				fmt.Printf("Project: %s\n", cfg.ProjectPath)
+			}
+			if cfg.Profile != "" {
+				fmt.Printf("Profile: %s\n", cfg.Profile)
+			}
+			if len(cfg.Profiles) > 0 {
+				fmt.Printf("Profiles: %s\n", strings.Join(cfg.Profiles, ", "))
+			}
+			for _, key := range cfg.GetKeys() {
+				val := cfg.GetString(key)
+				if val == "" {
+					val = fmt.Sprint(cfg.Values[key])
+				}
+				fmt.Printf("%s: %s (%s)\n", key, val, cfg.GetSource(key))
+			}
+			return nil
+		},
+	}
+	return c
+}
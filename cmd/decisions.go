@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+	"github.com/spf13/cobra"
+)
+
+func decisionsCommand() *cobra.Command {
+	var (
+		players bool
+		all     bool
+		re      reArgs
+	)
+	c := &cobra.Command{
+		Use:   "decisions",
+		Short: "Analyze bunt and steal decisions against their break even success rates",
+		Long: `Print the break even success rate of sacrifice bunts, bunts for hits, and
+steals of 2nd and 3rd in each base/out state, the observed success rates,
+and the attempts whose expected value was negative given the team's success
+rate.  Without an RE matrix, the observed run expectancy of the games is used.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games, err := game.ReadGameFiles(args)
+			if err != nil {
+				return err
+			}
+			runExpectancy, err := re.getRunExpectancyOrObserved(games)
+			if err != nil {
+				return err
+			}
+			da := stats.NewDecisionAnalysis(runExpectancy)
+			for _, g := range games {
+				da.Read(g)
+			}
+			return render(cmd,
+				da.GetBreakEvenData(),
+				da.GetSuccessData(players),
+				da.GetAttemptsData(!all),
+			)
+		},
+	}
+	re.registerFlags(c.Flags())
+	c.Flags().BoolVar(&players, "players", false, "Show success rates per player")
+	c.Flags().BoolVar(&all, "all", false, "Show all attempts, not just those with a negative expected value")
+	return c
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+	"github.com/spf13/cobra"
+)
+
+func defenseCommand() *cobra.Command {
+	var (
+		us         string
+		byPosition bool
+		months     bool
+		re         reArgs
+	)
+	c := &cobra.Command{
+		Use:   "defense",
+		Short: "Print a defensive runs saved leaderboard from errors, alt plays and double plays",
+		Long: `Print the runs saved by each fielder.  Errors are priced by the change in
+run expectancy against the out that would have been made, alt plays by the
+change in run expectancy of the alternative, and double plays against a
+single force out.  Without an RE matrix, the observed run expectancy of the
+games is used.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games, err := game.ReadGameFiles(args)
+			if err != nil {
+				return err
+			}
+			runExpectancy, err := re.getRunExpectancyOrObserved(games)
+			if err != nil {
+				return err
+			}
+			d := stats.NewDefense(runExpectancy)
+			for _, g := range games {
+				d.Read(g)
+			}
+			dat := d.GetData(byPosition)
+			if months {
+				dat = d.GetMonthlyData()
+			}
+			if us != "" {
+				idx := dat.GetIndex()
+				dat = dat.RFilter(func(row int) bool {
+					return strings.HasPrefix(strings.ToLower(idx.GetString(row, "Team")), strings.ToLower(us))
+				})
+			}
+			return render(cmd, dat)
+		},
+	}
+	re.registerFlags(c.Flags())
+	c.Flags().StringVar(&us, "us", "", "Only show fielders on the team with this name")
+	c.Flags().BoolVar(&byPosition, "by-position", false, "Break down the runs saved by position")
+	c.Flags().BoolVar(&months, "months", false, "Show the runs saved by month")
+	return c
+}
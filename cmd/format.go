@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/spf13/cobra"
+)
+
+// getFormat returns the output format from --format, or csv or yaml if
+// the command has the older --csv or --yaml flag and it's set.
+func getFormat(cmd *cobra.Command) (dataframe.Format, error) {
+	if csv, err := cmd.Flags().GetBool("csv"); err == nil && csv {
+		return dataframe.CSVFormat, nil
+	}
+	if yaml, err := cmd.Flags().GetBool("yaml"); err == nil && yaml {
+		return dataframe.YAMLFormat, nil
+	}
+	format, _ := cmd.Flags().GetString("format")
+	return dataframe.ParseFormat(format)
+}
+
+// render prints tables in the output format.
+func render(cmd *cobra.Command, tables ...*dataframe.Data) error {
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+	return dataframe.Render(os.Stdout, format, tables...)
+}
+
+// deprecateCSV marks a command's --csv flag as replaced by --format csv.
+func deprecateCSV(c *cobra.Command) {
+	_ = c.Flags().MarkDeprecated("csv", "use --format csv")
+}
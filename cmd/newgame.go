@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"path/filepath"
+
 	"github.com/slshen/paperscore/pkg/gamefile"
 	"github.com/spf13/cobra"
 )
 
 func newGameCommand() *cobra.Command {
 	var (
-		nextDay bool
-		count   int
+		nextDay   bool
+		count     int
+		sheet     bool
+		svgFormat bool
 	)
 	c := &cobra.Command{
 		Use:  "new-game",
@@ -24,6 +28,16 @@ func newGameCommand() *cobra.Command {
 					return err
 				}
 				nextDay = false
+				if sheet || svgFormat {
+					// reparse to get the properties of the new game
+					ng, err = gamefile.ParseFile(ng.Path)
+					if err != nil {
+						return err
+					}
+					if err := writeScoresheet(ng, filepath.Dir(ng.Path), svgFormat); err != nil {
+						return err
+					}
+				}
 				gm = ng
 			}
 			return nil
@@ -31,5 +45,7 @@ func newGameCommand() *cobra.Command {
 	}
 	c.Flags().BoolVarP(&nextDay, "next-day", "n", false, "Create a game for the next day")
 	c.Flags().IntVar(&count, "count", 1, "Create `N` new games")
+	c.Flags().BoolVar(&sheet, "sheet", false, "Also write a PDF scoresheet and lineup cards for each new game")
+	c.Flags().BoolVar(&svgFormat, "svg", false, "Write the scoresheet as an SVG for each page")
 	return c
 }
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/slshen/paperscore/pkg/game"
 	"github.com/slshen/paperscore/pkg/stats"
 	"github.com/spf13/cobra"
@@ -25,8 +23,7 @@ func pitchingTimesSeenLineupCommand() *cobra.Command {
 			for _, g := range games {
 				b.Record(g)
 			}
-			fmt.Println(b.GetData())
-			return nil
+			return render(cmd, b.GetData())
 		},
 	}
 	flags := c.Flags()
@@ -12,17 +12,25 @@ import (
 
 func reCommand() *cobra.Command {
 	var (
-		csv        bool
-		yamlFormat bool
-		freq       bool
-		pivot      bool
-		raw        bool
-		plain      bool
+		csv    bool
+		yaml   bool
+		freq   bool
+		pivot  bool
+		raw    bool
+		plain  bool
+		smooth bool
+		prior  string
 	)
 	re24 := &stats.ObservedRunExpectancy{}
+	smoothed := stats.NewSmoothedRunExpectancy(nil)
 	c := &cobra.Command{
 		Use:   "re",
 		Short: "Determine the run expectancy matrix",
+		Long: `Determine the run expectancy matrix from the runs scored in games.
+
+With --smooth, the observed matrix is shrunk toward the --prior matrix, then
+smoothed so that more outs never raise the expected runs and more runners
+never lower them.  Bootstrap confidence intervals are shown for each state.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			games, err := game.ReadGames(args)
 			if err != nil {
@@ -33,11 +41,31 @@ func reCommand() *cobra.Command {
 					return err
 				}
 			}
-			if yamlFormat {
-				return re24.WriteYAML(os.Stdout)
+			if prior != "" {
+				smooth = true
+				smoothed.Prior, err = stats.ReadREMatrix(prior)
+				if err != nil {
+					return err
+				}
+			}
+			if smooth && (raw || pivot || freq) {
+				return fmt.Errorf("--smooth cannot be used with --raw, --pivot or --freq")
 			}
 			var data *dataframe.Data
 			switch {
+			case smooth:
+				if err := smoothed.Smooth(re24); err != nil {
+					return err
+				}
+				data = smoothed.GetData()
+				if plain {
+					data = data.Select(
+						dataframe.Col("Runr"),
+						dataframe.Col("0Out"),
+						dataframe.Col("1Out"),
+						dataframe.Col("2Out"),
+					)
+				}
 			case raw:
 				data = re24.GetRunData()
 			case pivot || freq:
@@ -58,19 +86,23 @@ func reCommand() *cobra.Command {
 					)
 				}
 			}
-			if csv {
-				return data.RenderCSV(os.Stdout, false)
-			}
-			fmt.Println(data)
-			return nil
+			return render(cmd, data)
 		},
 	}
 	c.Flags().BoolVar(&csv, "csv", false, "Print in CSV format")
-	c.Flags().BoolVar(&yamlFormat, "yaml", false, "Print in YAML format")
+	c.Flags().BoolVar(&yaml, "yaml", false, "Print in YAML format")
+	deprecateCSV(c)
+	_ = c.Flags().MarkDeprecated("yaml", "use --format yaml")
 	c.Flags().BoolVar(&freq, "freq", false, "Print the frequency of # runs scored per 24-base/out state")
 	c.Flags().BoolVar(&pivot, "pivot", false, "Pivot the frequency data by runs")
 	c.Flags().BoolVar(&raw, "raw", false, "Get the raw run data")
 	c.Flags().BoolVar(&plain, "plain", false, "Write data without counts")
+	c.Flags().BoolVar(&smooth, "smooth", false, "Smooth the matrix and show confidence intervals")
+	c.Flags().StringVar(&prior, "prior", "", "Shrink toward the RE matrix in the CSV `file`")
+	c.Flags().Float64Var(&smoothed.PriorWeight, "prior-weight", smoothed.PriorWeight,
+		"The number of observations the prior is worth")
+	c.Flags().IntVar(&smoothed.Samples, "samples", smoothed.Samples, "The number of bootstrap samples")
+	c.Flags().Float64Var(&smoothed.Confidence, "confidence", smoothed.Confidence, "The confidence interval")
 	return c
 }
 
@@ -90,10 +122,79 @@ func reAnalysisCommand() *cobra.Command {
 				return fmt.Errorf("no RE specified")
 			}
 			rea := stats.NewREAnalysis(re)
-			fmt.Println(rea.Run())
-			return nil
+			return render(cmd, rea.Run())
 		},
 	}
 	re.registerFlags(c.Flags())
 	return c
 }
+
+func reCompareCommand() *cobra.Command {
+	var (
+		gameDirs []string
+		us       string
+		top      int
+	)
+	c := &cobra.Command{
+		Use:   "re-compare matrix.csv matrix.csv...",
+		Short: "Compare run expectancy matrices",
+		Long: `Compare run expectancy matrices state by state against the first matrix.
+With --games, also show the RE24 of each batter and the alt play cost of each
+player when each matrix is used.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := getFormat(cmd)
+			if err != nil {
+				return err
+			}
+			comparison := &stats.REComparison{}
+			var names []string
+			for i, path := range args {
+				re, err := stats.ReadREMatrix(path)
+				if err != nil {
+					return err
+				}
+				name := string(rune('A' + i))
+				comparison.Add(name, re)
+				names = append(names, name)
+			}
+			var tables []*dataframe.Data
+			if format == dataframe.TextFormat {
+				for i, name := range names {
+					fmt.Printf("%s = %s\n", name, args[i])
+				}
+				fmt.Println()
+			} else {
+				tables = append(tables, &dataframe.Data{
+					Name: "Matrices",
+					Columns: []*dataframe.Column{
+						{Name: "Matrix", Values: names},
+						{Name: "Path", Values: args},
+					},
+				})
+			}
+			tables = append(tables, comparison.GetMatrixData())
+			if len(gameDirs) > 0 {
+				games, err := game.ReadGames(gameDirs)
+				if err != nil {
+					return err
+				}
+				for _, g := range games {
+					if err := comparison.Read(g); err != nil {
+						return err
+					}
+				}
+				re24 := comparison.GetRE24Data(us)
+				if top > 0 {
+					re24 = re24.RFilter(func(row int) bool { return row < top })
+				}
+				tables = append(tables, re24, comparison.GetAltData())
+			}
+			return dataframe.Render(os.Stdout, format, tables...)
+		},
+	}
+	c.Flags().StringSliceVar(&gameDirs, "games", nil, "Show the effect of each matrix on the games in `dir`")
+	c.Flags().StringVar(&us, "us", "", "Only show RE24 for batters on `team`")
+	c.Flags().IntVar(&top, "top", 0, "Only show the top `n` batters by RE24")
+	return c
+}
@@ -39,3 +39,19 @@ func (re *reArgs) getRunExpectancy() (stats.RunExpectancy, error) {
 	}
 	return nil, nil
 }
+
+// getRunExpectancyOrObserved returns the configured RE, or the observed
+// RE of games if none is configured.
+func (re *reArgs) getRunExpectancyOrObserved(games []*game.Game) (stats.RunExpectancy, error) {
+	runExpectancy, err := re.getRunExpectancy()
+	if err != nil || runExpectancy != nil {
+		return runExpectancy, err
+	}
+	observed := &stats.ObservedRunExpectancy{}
+	for _, g := range games {
+		if err := observed.Read(g); err != nil {
+			return nil, err
+		}
+	}
+	return observed, nil
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/spf13/cobra"
+)
+
+type reconcileRow struct {
+	Inning   int
+	Half     string
+	Runs     int
+	Score    int
+	Recorded string
+	Check    string
+}
+
+type reconcileDetail struct {
+	Inning int
+	Half   string
+	Pos    string
+	Detail string
+}
+
+func reconcileCommand() *cobra.Command {
+	var all bool
+	c := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Compare the score and final lines of games with the scores computed from the plays",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games, err := game.ReadGameFiles(args)
+			var tables []*dataframe.Data
+			for _, g := range games {
+				if g == nil {
+					continue
+				}
+				tables = append(tables, reconcileGame(g, all)...)
+			}
+			if renderErr := render(cmd, tables...); renderErr != nil {
+				return renderErr
+			}
+			return err
+		},
+	}
+	c.Flags().BoolVar(&all, "all", false, "Print every inning, not just games with mismatches")
+	return c
+}
+
+// reconcileGame returns the inning scores of g, and the scoring plays and
+// checks of the innings that don't match, if any don't or all is set.
+func reconcileGame(g *game.Game, all bool) []*dataframe.Data {
+	dat := &dataframe.Data{Name: g.ID}
+	var (
+		idx        *dataframe.Index
+		mismatches []*game.InningScore
+	)
+	for _, inning := range g.GetInningScores() {
+		row := reconcileRow{
+			Inning: inning.InningNumber,
+			Half:   string(inning.Half),
+			Runs:   inning.Runs,
+			Score:  inning.Score,
+			Check:  "ok",
+		}
+		var recorded, checks []string
+		for _, check := range inning.Checks {
+			s := strconv.Itoa(check.Recorded)
+			if check.Final {
+				s = fmt.Sprintf("final %s", s)
+			}
+			recorded = append(recorded, s)
+			if !check.Matches() {
+				checks = append(checks, check.Explain())
+			}
+		}
+		row.Recorded = strings.Join(recorded, ", ")
+		if len(inning.Checks) == 0 {
+			row.Check = ""
+		}
+		if len(checks) > 0 {
+			row.Check = strings.Join(checks, ", ")
+			mismatches = append(mismatches, inning)
+		}
+		idx = dat.AppendStruct(idx, row)
+	}
+	if len(mismatches) == 0 && !all {
+		return nil
+	}
+	tables := []*dataframe.Data{dat.Select(
+		dataframe.Rename("Inning", "I").WithFormat("%2d"),
+		dataframe.Col("Half").WithFormat("%-6s"),
+		dataframe.Col("Runs").WithFormat("%4d"),
+		dataframe.Col("Score").WithFormat("%5d"),
+		dataframe.Col("Recorded").WithFormat("%-8s"),
+		dataframe.Col("Check").WithFormat("%-20s"),
+	)}
+	if len(mismatches) == 0 {
+		return tables
+	}
+	details := &dataframe.Data{}
+	idx = nil
+	for _, inning := range mismatches {
+		for _, state := range inning.ScoringPlays {
+			idx = details.AppendStruct(idx, reconcileDetail{
+				Inning: inning.InningNumber,
+				Half:   string(inning.Half),
+				Pos:    fmt.Sprintf("%s:%d", state.Pos.Filename, state.Pos.Line),
+				Detail: fmt.Sprintf("%s (%d)", state.GetPlayAdvancesCode(), len(state.ScoringRunners)),
+			})
+		}
+		for _, check := range inning.Checks {
+			if !check.Matches() {
+				idx = details.AppendStruct(idx, reconcileDetail{
+					Inning: inning.InningNumber,
+					Half:   string(inning.Half),
+					Pos:    fmt.Sprintf("%s:%d", check.Pos.Filename, check.Pos.Line),
+					Detail: check.Explain(),
+				})
+			}
+		}
+	}
+	details = details.Select(
+		dataframe.Rename("Inning", "I").WithFormat("%2d"),
+		dataframe.Col("Half").WithFormat("%-6s"),
+		dataframe.Col("Pos").WithFormat("%-30s"),
+		dataframe.Col("Detail").WithFormat("%-30s"),
+	)
+	details.Name = fmt.Sprintf("%s scoring plays and mismatches", g.ID)
+	return append(tables, details)
+}
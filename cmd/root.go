@@ -3,28 +3,47 @@ package cmd
 import (
 	"os"
 
+	"github.com/slshen/paperscore/pkg/config"
+	"github.com/slshen/paperscore/pkg/dataframe"
 	"github.com/spf13/cobra"
 )
 
 func Root() *cobra.Command {
-	var dir string
+	var dir, profile, format string
 	root := &cobra.Command{
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if dir != "" {
-				return os.Chdir(dir)
+				if err := os.Chdir(dir); err != nil {
+					return err
+				}
 			}
-			return nil
+			paths := args
+			if f := cmd.Flags().Lookup("data"); f != nil && f.Value.String() != "" {
+				paths = append(paths, f.Value.String())
+			}
+			cfg, err := config.Load(profile, paths)
+			if err != nil {
+				return err
+			}
+			if err := applyConfig(cmd, cfg); err != nil {
+				return err
+			}
+			_, err = dataframe.ParseFormat(format)
+			return err
 		},
 	}
 	root.SilenceUsage = true
 	root.PersistentFlags().StringVar(&dir, "working-dir", "", "Change working directory to `dir`")
-	root.AddCommand(readCommand(), boxCommand(), playByPlayCommand(),
-		statsCommand("batting"), statsCommand("pitching"), reCommand(),
-		tournamentCommand(), reAnalysisCommand(),
-		fmtCommand(), altCommand(), dataExportCommand(), newGameCommand(),
-		battingCountCommand(), battingTimesSeenPitcherCommand(),
+	root.PersistentFlags().StringVar(&profile, "profile", "", "Use the settings of `profile` in paperscore.yaml")
+	root.PersistentFlags().StringVar(&format, "format", string(dataframe.TextFormat),
+		"Print reports in `format`, one of "+dataframe.FormatNames())
+	root.AddCommand(readCommand(), boxCommand(), scorebookCommand(), playByPlayCommand(),
+		statsCommand("batting"), statsCommand("pitching"), baserunningCommand(), catchingCommand(), workloadCommand(), scoutCommand(), weightsCommand(), decisionsCommand(), defenseCommand(), reCommand(),
+		tournamentCommand(), reAnalysisCommand(), reCompareCommand(),
+		fmtCommand(), altCommand(), dataExportCommand(), newGameCommand(), scoresheetCommand(),
+		battingCountCommand(), runningCountCommand(), battingTimesSeenPitcherCommand(),
 		pitchingTimesSeenLineupCommand(), simCommand(),
-		uiCommand(),
+		reconcileCommand(), uiCommand(), webCommand(), apiCommand(), configCommand(),
 	)
 	return root
 }
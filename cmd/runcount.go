@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+	"github.com/spf13/cobra"
+)
+
+func runningCountCommand() *cobra.Command {
+	var (
+		us    string
+		notus string
+	)
+	c := &cobra.Command{
+		Use:   "running-count",
+		Short: "Display stolen base success and wild pitches by the count when they happened",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rc := stats.NewRunningByCount()
+			rc.Us = us
+			rc.NotUs = notus
+			gs, err := game.ReadGames(args)
+			if err != nil {
+				return err
+			}
+			for _, gm := range gs {
+				rc.Read(gm)
+			}
+			return render(cmd, rc.GetData())
+		},
+	}
+	c.Flags().StringVar(&us, "us", "", "Limit to running plays by team ID's that contain `us`")
+	c.Flags().StringVar(&notus, "not-us", "", "Limit to running plays by team ID's that do not contain `us`")
+	return c
+}
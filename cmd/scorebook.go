@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/scorebook"
+	"github.com/spf13/cobra"
+)
+
+type scorebookCmd struct {
+	*cobra.Command
+	svgFormat bool
+	pdfFormat bool
+	outputDir string
+}
+
+func (s *scorebookCmd) getPath(g *game.Game, ext string) string {
+	base := baseName(g.File.Path)
+	return filepath.Join(s.outputDir, fmt.Sprintf("%s.%s", base, ext))
+}
+
+func (s *scorebookCmd) run(games []*game.Game) error {
+	if s.svgFormat && s.pdfFormat {
+		return fmt.Errorf("only one of --svg and --pdf can be given")
+	}
+	if s.svgFormat && len(games) > 1 && s.outputDir == "" {
+		return fmt.Errorf("--outdir is required for the SVG of more than one game")
+	}
+	if s.pdfFormat && s.outputDir == "" {
+		// one page per game
+		var pages []scorebook.Drawing
+		for _, g := range games {
+			pages = append(pages, scorebook.New(g))
+		}
+		return scorebook.WritePDF(os.Stdout, pages)
+	}
+	for _, g := range games {
+		sb := scorebook.New(g)
+		write := func(out io.Writer) error {
+			switch {
+			case s.svgFormat:
+				return scorebook.WriteSVG(out, sb)
+			case s.pdfFormat:
+				return scorebook.WritePDF(out, []scorebook.Drawing{sb})
+			}
+			err := sb.WriteText(out)
+			if err == nil && s.outputDir == "" {
+				_, err = fmt.Fprintln(out)
+			}
+			return err
+		}
+		if s.outputDir == "" {
+			if err := write(os.Stdout); err != nil {
+				return err
+			}
+			continue
+		}
+		ext := "txt"
+		switch {
+		case s.svgFormat:
+			ext = "svg"
+		case s.pdfFormat:
+			ext = "pdf"
+		}
+		f, err := os.Create(s.getPath(g, ext))
+		if err != nil {
+			return err
+		}
+		if err := write(f); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *scorebookCmd) init() *cobra.Command {
+	s.Command = &cobra.Command{
+		Use:   "scorebook",
+		Short: "Draw games as a traditional scorebook grid",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games, err := game.ReadGames(args)
+			if err != nil {
+				return err
+			}
+			return s.run(games)
+		},
+	}
+	flags := s.Flags()
+	flags.BoolVar(&s.svgFormat, "svg", false, "Draw the scorebook as SVG")
+	flags.BoolVar(&s.pdfFormat, "pdf", false, "Draw the scorebook as PDF, a page for each game")
+	flags.StringVar(&s.outputDir, "outdir", "", "Write individual scorebooks to this directory")
+	return s.Command
+}
+
+func scorebookCommand() *cobra.Command {
+	var s scorebookCmd
+	return s.init()
+}
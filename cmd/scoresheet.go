@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/slshen/paperscore/pkg/scorebook"
+	"github.com/spf13/cobra"
+)
+
+// writeScoresheet writes the scoresheet for the game file to outputDir,
+// as a PDF or as an SVG for each page.
+func writeScoresheet(gf *gamefile.File, outputDir string, svgFormat bool) error {
+	sheet, err := scorebook.NewScoresheet(gf)
+	if err != nil {
+		return err
+	}
+	base := baseName(gf.Path)
+	pages := sheet.GetPages()
+	if !svgFormat {
+		return writeScoresheetFile(filepath.Join(outputDir, fmt.Sprintf("%s-sheet.pdf", base)),
+			func(f *os.File) error {
+				return scorebook.WritePDF(f, pages)
+			})
+	}
+	for i, page := range pages {
+		if err := writeScoresheetFile(filepath.Join(outputDir, fmt.Sprintf("%s-sheet-%d.svg", base, i+1)),
+			func(f *os.File) error {
+				return scorebook.WriteSVG(f, page)
+			}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeScoresheetFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func scoresheetCommand() *cobra.Command {
+	var (
+		svgFormat bool
+		outputDir string
+	)
+	c := &cobra.Command{
+		Use:   "scoresheet",
+		Short: "Generate printable blank scoresheets and lineup cards for games",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, arg := range args {
+				gf, err := gamefile.ParseFile(arg)
+				if err != nil {
+					return err
+				}
+				dir := outputDir
+				if dir == "" {
+					dir = filepath.Dir(arg)
+				}
+				if err := writeScoresheet(gf, dir, svgFormat); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	c.Flags().BoolVar(&svgFormat, "svg", false, "Write an SVG for each page instead of a PDF")
+	c.Flags().StringVar(&outputDir, "outdir", "", "Write scoresheets to this directory instead of next to the games")
+	return c
+}
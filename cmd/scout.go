@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/scout"
+	"github.com/spf13/cobra"
+)
+
+func scoutCommand() *cobra.Command {
+	var (
+		team string
+		html bool
+	)
+	c := &cobra.Command{
+		Use:   "scout",
+		Short: "Print a scouting report for an opponent from all the games played against them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if team == "" {
+				return fmt.Errorf("--team is required")
+			}
+			games, err := game.ReadGames(args)
+			if err != nil {
+				return err
+			}
+			rep, err := scout.NewReport(team, games)
+			if err != nil {
+				return err
+			}
+			if len(rep.Games) == 0 {
+				return fmt.Errorf("no games against %s", team)
+			}
+			format, err := getFormat(cmd)
+			if err != nil {
+				return err
+			}
+			switch {
+			case html || format == dataframe.HTMLFormat:
+				return rep.RenderHTML(os.Stdout)
+			case format == dataframe.TextFormat || format == dataframe.MarkdownFormat:
+				return rep.RenderMarkdown(os.Stdout)
+			}
+			return dataframe.Render(os.Stdout, format,
+				rep.GetHittersData(), rep.GetPitchersData(), rep.GetRunsData())
+		},
+	}
+	c.Flags().StringVar(&team, "team", "", "The opponent team `id` or name")
+	c.Flags().BoolVar(&html, "html", false, "Print the report in HTML instead of Markdown")
+	_ = c.Flags().MarkDeprecated("html", "use --format html")
+	return c
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/slshen/paperscore/pkg/dataframe"
 	"github.com/slshen/paperscore/pkg/markov"
 	"github.com/slshen/paperscore/pkg/markov/expr"
 	"github.com/spf13/cobra"
@@ -53,16 +54,24 @@ func simCommand() *cobra.Command {
 				}
 				runs += sim.Runs
 			}
-			fmt.Printf("%d games %f runs %f runs/game\n", games, runs, runs/float64(games))
 			re := sim.GetExpectedRuns()
-			fmt.Printf("Rnr  0out  1out  2out\n")
+			dat := &dataframe.Data{
+				Name: fmt.Sprintf("%d games %f runs %f runs/game", games, runs, runs/float64(games)),
+				Columns: []*dataframe.Column{
+					dataframe.NewColumn("Rnr", "%3s", dataframe.EmptyStrings),
+				},
+			}
+			for outs := 0; outs < 3; outs++ {
+				dat.Columns = append(dat.Columns,
+					dataframe.NewColumn(fmt.Sprintf("%dout", outs), "%4.2f", dataframe.EmptyFloats))
+			}
 			for i := 0; i < 8; i++ {
-				out0 := markov.BaseOutState(0 + i)
-				out1 := markov.BaseOutState(8 + i)
-				out2 := markov.BaseOutState(16 + i)
-				fmt.Printf("%s  %4.2f  %4.2f  %4.2f\n", out0.String()[1:4], re[out0], re[out1], re[out2])
+				dat.Columns[0].AppendString(markov.BaseOutState(i).String()[1:4])
+				for outs := 0; outs < 3; outs++ {
+					dat.Columns[outs+1].AppendFloat(re[markov.BaseOutState(8*outs+i)])
+				}
 			}
-			return nil
+			return render(cmd, dat)
 		},
 	}
 	flags := c.Flags()
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/slshen/paperscore/pkg/dataframe"
 	"github.com/slshen/paperscore/pkg/game"
@@ -40,15 +39,11 @@ func statsCommand(statsType string) *cobra.Command {
 			} else {
 				data = mg.GetPitchingData()
 			}
-			if csv {
-				return data.RenderCSV(os.Stdout, true)
-			} else {
-				fmt.Println(data)
-			}
-			return nil
+			return render(cmd, data)
 		},
 	}
 	re.registerFlags(c.Flags())
 	c.Flags().BoolVar(&csv, "csv", false, "Print in CSV format")
+	deprecateCSV(c)
 	return c
 }
@@ -33,6 +33,7 @@ func tournamentCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			var tables []*dataframe.Data
 			for _, gr := range tournament.GroupByTournament(games) {
 				if tournamentName != "" && !strings.Contains(strings.ToLower(gr.Name), tournamentName) {
 					continue
@@ -42,7 +43,7 @@ func tournamentCommand() *cobra.Command {
 					return err
 				}
 				if !playsOnly {
-					fmt.Println(rep.GetBattingData())
+					tables = append(tables, rep.GetBattingData())
 				}
 				topPlays := rep.GetBestAndWorstRE24(plays)
 				if playsOnly {
@@ -56,9 +57,9 @@ func tournamentCommand() *cobra.Command {
 						dataframe.Col("RE24"),
 					)
 				}
-				fmt.Println(topPlays)
+				tables = append(tables, topPlays)
 			}
-			return nil
+			return render(cmd, tables...)
 		},
 	}
 	re.registerFlags(c.Flags())
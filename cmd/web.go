@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/slshen/paperscore/pkg/web"
+	"github.com/spf13/cobra"
+)
+
+func webCommand() *cobra.Command {
+	var (
+		addr string
+		re   reArgs
+	)
+	c := &cobra.Command{
+		Use:   "web [dir]",
+		Short: "Serve a browser app to edit and review the games in a directory",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) == 1 {
+				dir = args[0]
+			}
+			re, err := re.getRunExpectancy()
+			if err != nil {
+				return err
+			}
+			log.Printf("Serving games in %s at http://%s", dir, addr)
+			return http.ListenAndServe(addr, web.NewServer(dir, re))
+		},
+	}
+	c.Flags().StringVar(&addr, "addr", ":8080", "Listen on `address`, e.g. :8080 to serve other devices on the network")
+	re.registerFlags(c.Flags())
+	return c
+}
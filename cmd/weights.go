@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+	"github.com/spf13/cobra"
+)
+
+func weightsCommand() *cobra.Command {
+	var (
+		re reArgs
+	)
+	c := &cobra.Command{
+		Use:   "weights",
+		Short: "Print the linear weights and wOBA weights derived from the run expectancy",
+		Long: `Print the linear weights and wOBA weights derived from the run expectancy.
+Without an RE matrix, the observed run expectancy of the games is used.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			games, err := game.ReadGameFiles(args)
+			if err != nil {
+				return err
+			}
+			runExpectancy, err := re.getRunExpectancyOrObserved(games)
+			if err != nil {
+				return err
+			}
+			weights := stats.NewWeights(runExpectancy)
+			for _, g := range games {
+				weights.Read(g)
+			}
+			return render(cmd, weights.GetData(), weights.GetScaleData())
+		},
+	}
+	re.registerFlags(c.Flags())
+	return c
+}
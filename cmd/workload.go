@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slshen/paperscore/pkg/config"
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/tournament"
+	"github.com/spf13/cobra"
+)
+
+func workloadCommand() *cobra.Command {
+	var (
+		us       string
+		rules    tournament.RestRules
+		rest     string
+		forecast bool
+		nextDay  bool
+	)
+	c := &cobra.Command{
+		Use:     "pitcher-workload",
+		Aliases: []string{"workload"},
+		Short:   "Report pitches per game, per day and over three days, and flag pitchers over the limits",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if rest == "" {
+				rest = config.GetConfig().GetString("rest_rules")
+			}
+			var err error
+			rules.Rest, err = tournament.ParseRestRules(rest)
+			if err != nil {
+				return err
+			}
+			games, err := game.ReadGameFiles(args)
+			if err != nil {
+				return err
+			}
+			w := tournament.NewWorkload(rules, games)
+			if forecast {
+				if us == "" {
+					return fmt.Errorf("--us is required with --forecast")
+				}
+				date := nextGameDate(games, nextDay)
+				dat := tournament.GetAvailabilityData(w.Forecast(us, date))
+				dat.Name = fmt.Sprintf("Available %s", date.Format("01/02/2006"))
+				return render(cmd, dat)
+			}
+			dat := w.GetData()
+			if us != "" {
+				idx := dat.GetIndex()
+				dat = dat.RFilter(func(row int) bool {
+					return strings.HasPrefix(strings.ToLower(idx.GetString(row, "Team")), strings.ToLower(us))
+				})
+			}
+			return render(cmd, dat.Select(
+				dataframe.Col("Date"),
+				dataframe.Col("Game"),
+				dataframe.Col("Team"),
+				dataframe.Col("Name"),
+				dataframe.Col("PC"),
+				dataframe.Col("BF"),
+				dataframe.Col("Day"),
+				dataframe.Col("3Day"),
+				dataframe.Col("Rest"),
+				dataframe.Col("Flags"),
+			))
+		},
+	}
+	c.Flags().StringVar(&us, "us", "", "Only show pitchers for `team`")
+	c.Flags().IntVar(&rules.MaxGame, "max-game", 0, "Flag more than `N` pitches in a game")
+	c.Flags().IntVar(&rules.MaxDay, "max-day", 0, "Flag more than `N` pitches in a day")
+	c.Flags().IntVar(&rules.MaxThreeDays, "max-3day", 0, "Flag more than `N` pitches in three days")
+	c.Flags().StringVar(&rest, "rest", "", "Rest `rules` as pitches:days, e.g. 36:1,51:2,66:3")
+	c.Flags().BoolVar(&forecast, "forecast", false, "Show who is available for the next game")
+	c.Flags().BoolVarP(&nextDay, "next-day", "n", false, "Forecast for a game on the day after the last game")
+	return c
+}
+
+// nextGameDate returns the date of the game new-game would create after
+// the last game.
+func nextGameDate(games []*game.Game, nextDay bool) time.Time {
+	var date time.Time
+	for _, g := range games {
+		for _, d := range g.GetDates() {
+			if d.After(date) {
+				date = d
+			}
+		}
+	}
+	if nextDay {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
@@ -7,6 +7,7 @@ require (
 	github.com/gdamore/tcell/v2 v2.7.1
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/kr/text v0.2.0
+	github.com/mattn/go-runewidth v0.0.15
 	github.com/mitchellh/go-wordwrap v1.0.1
 	github.com/mitchellh/mapstructure v1.5.0
 	github.com/rivo/tview v0.0.0-20240921122403-a64fc48d7654
@@ -23,7 +24,6 @@ require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
-	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	golang.org/x/sys v0.17.0 // indirect
@@ -2,7 +2,7 @@
 
 set -euo pipefail
 
-go run main.go re --plain --csv data > data/observed_re.csv
+go run main.go re --plain --format csv data > data/observed_re.csv
 
 for f in d1_softball_re_2019.csv \
     d1_softball_re_2022.csv \
@@ -172,9 +172,14 @@ func (box *BoxScore) AltPlaysPerPlayer() *dataframe.Data {
 	idx := dat.GetIndex()
 	idx.GetColumn("Player").Format = "%-20s"
 	dat.RApply(func(row int) {
-		player := box.Game.GetPlayer(game.PlayerID(idx.GetString(row, "Player")))
+		team := box.Game.Visitor
+		if idx.GetString(row, "Team") == box.Game.Home.Name {
+			team = box.Game.Home
+		}
+		player := team.GetPlayer(game.PlayerID(idx.GetString(row, "Player")))
 		idx.GetColumn("Player").GetStrings()[row] = player.GetShortName()
 	})
+	dat.RemoveColumn("Team")
 	return dat
 }
 
@@ -201,3 +206,32 @@ func (box *BoxScore) Render(w io.Writer) error {
 	}
 	return tmpl.ExecuteTemplate(w, "box.tmpl", box)
 }
+
+// Tables returns the tables of the box score, each named for what it
+// shows, for formats other than text.
+func (box *BoxScore) Tables() []*dataframe.Data {
+	score := box.InningScoreTable()
+	score.Name = "Line Score"
+	score.RemoveColumn(" -")
+	score.Columns[0].Name = "Team"
+	for _, col := range score.Columns {
+		col.Name = strings.TrimSpace(col.Name)
+	}
+	tables := []*dataframe.Data{score}
+	for _, side := range []struct {
+		team   *game.Team
+		lineup *Lineup
+	}{{box.Game.Visitor, box.VisitorLineup}, {box.Game.Home, box.HomeLineup}} {
+		batting := side.lineup.PlayerTable()
+		batting.Name = fmt.Sprintf("%s Batting", side.team.Name)
+		pitching := side.lineup.PitchingTable()
+		pitching.Name = fmt.Sprintf("%s Pitching", side.team.Name)
+		tables = append(tables, batting, pitching)
+		if side.lineup.HaveCatching() {
+			catching := side.lineup.CatchingTable()
+			catching.Name = fmt.Sprintf("%s Catching", side.team.Name)
+			tables = append(tables, catching)
+		}
+	}
+	return append(tables, box.AltPlays(), box.AltPlaysPerPlayer())
+}
@@ -90,6 +90,22 @@ func (lineup *Lineup) PitchingTable() *dataframe.Data {
 	return dat
 }
 
+func (lineup *Lineup) HaveCatching() bool {
+	return len(lineup.Catchers) > 0
+}
+
+func (lineup *Lineup) CatchingTable() *dataframe.Data {
+	dat := lineup.GetCatchingData().Select(
+		dataframe.Rename("Name", "Catcher"),
+		dataframe.Rename("Pitches", "PC"),
+		dataframe.Rename("StolenBases", "SB"),
+		dataframe.Rename("CaughtStealing", "CS"),
+		dataframe.Rename("PassedBalls", "PB"),
+		dataframe.Rename("PickedOff", "PO"),
+	)
+	return dat
+}
+
 func (lineup *Lineup) ErrorsList() string {
 	s := &strings.Builder{}
 	for _, f := range lineup.FieldingByPosition {
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -11,9 +13,36 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// ProjectFile is the name of the configuration file in a data directory.
+const ProjectFile = "paperscore.yaml"
+
+// Config is the settings from ~/.softball/config.yaml, then a project
+// paperscore.yaml, then a profile in it, then SOFTBALL_* environment
+// variables, each overriding the last.
 type Config struct {
 	Dir    string
 	Values map[string]any
+	// Sources are where each value came from
+	Sources map[string]string
+	// ProjectPath is the path of the paperscore.yaml that was read
+	ProjectPath string
+	// Profile is the name of the profile that was applied
+	Profile string
+	// Profiles are the names of the profiles in the project file
+	Profiles []string
+}
+
+// projectFile is the format of paperscore.yaml.  Settings other than
+// the profiles are at the top level.
+type projectFile struct {
+	Profile  string                    `yaml:"profile"`
+	Profiles map[string]map[string]any `yaml:"profiles"`
+}
+
+// pathKeys are settings that are file paths, which are relative to the
+// directory of the project file that sets them
+var pathKeys = map[string]bool{
+	"re_matrix": true,
 }
 
 var config *Config
@@ -22,33 +51,147 @@ func GetConfig() *Config {
 	if config != nil {
 		return config
 	}
-	config = &Config{}
-	home, err := os.UserHomeDir()
+	var err error
+	config, err = Load("", nil)
 	if err != nil {
-		return config
+		log.Default().Printf("Cannot load config - %s", err)
 	}
-	config.Dir = filepath.Join(home, ".softball")
-	configFile := filepath.Join(config.Dir, "config.yaml")
-	dat, err := os.ReadFile(configFile)
-	if err == nil {
-		if err := yaml.Unmarshal(dat, &config.Values); err != nil {
-			log.Default().Printf("Cannot read config %s - %s", configFile, err)
-			return config
+	return config
+}
+
+// Load reads the configuration, finding paperscore.yaml in the directory
+// of one of paths or the current directory, or one of their parents.  If
+// profile is empty the profile in SOFTBALL_PROFILE or the project file's
+// profile setting is used.  The configuration becomes the one returned
+// by GetConfig.
+func Load(profile string, paths []string) (*Config, error) {
+	c := &Config{
+		Values:  map[string]any{},
+		Sources: map[string]string{},
+	}
+	config = c
+	if home, err := os.UserHomeDir(); err == nil {
+		c.Dir = filepath.Join(home, ".softball")
+		configFile := filepath.Join(c.Dir, "config.yaml")
+		dat, err := os.ReadFile(configFile)
+		if err == nil {
+			var values map[string]any
+			if err := yaml.Unmarshal(dat, &values); err != nil {
+				log.Default().Printf("Cannot read config %s - %s", configFile, err)
+			} else {
+				c.set(values, configFile, "")
+				log.Default().Printf("Loaded config from %s", configFile)
+			}
 		}
-		log.Default().Printf("Loaded config from %s", configFile)
 	}
-	config.setFromEnviron()
-	return config
+	if err := c.loadProject(profile, paths); err != nil {
+		return c, err
+	}
+	c.setFromEnviron()
+	return c, nil
+}
+
+// FindProjectFile returns the first paperscore.yaml in the directory of
+// one of paths or the current directory, or one of their parents.
+func FindProjectFile(paths []string) string {
+	for _, path := range append(paths, ".") {
+		dir, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		if fi, err := os.Stat(dir); err == nil && !fi.IsDir() {
+			dir = filepath.Dir(dir)
+		}
+		for {
+			projectPath := filepath.Join(dir, ProjectFile)
+			if _, err := os.Stat(projectPath); err == nil {
+				return projectPath
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	return ""
+}
+
+func (c *Config) loadProject(profile string, paths []string) error {
+	if profile == "" {
+		profile = os.Getenv("SOFTBALL_PROFILE")
+	}
+	projectPath := FindProjectFile(paths)
+	if projectPath == "" {
+		if profile != "" {
+			return fmt.Errorf("profile %s cannot be used without a %s", profile, ProjectFile)
+		}
+		return nil
+	}
+	dat, err := os.ReadFile(projectPath)
+	if err != nil {
+		return err
+	}
+	var (
+		pf     projectFile
+		values map[string]any
+	)
+	if err := yaml.Unmarshal(dat, &pf); err != nil {
+		return fmt.Errorf("%s: %w", projectPath, err)
+	}
+	if err := yaml.Unmarshal(dat, &values); err != nil {
+		return fmt.Errorf("%s: %w", projectPath, err)
+	}
+	delete(values, "profile")
+	delete(values, "profiles")
+	c.ProjectPath = projectPath
+	c.set(values, projectPath, filepath.Dir(projectPath))
+	log.Default().Printf("Loaded config from %s", projectPath)
+	for name := range pf.Profiles {
+		c.Profiles = append(c.Profiles, name)
+	}
+	sort.Strings(c.Profiles)
+	if profile == "" {
+		profile = pf.Profile
+	}
+	if profile != "" {
+		values, ok := pf.Profiles[profile]
+		if !ok {
+			return fmt.Errorf("%s does not have a profile %s, it has %s", projectPath, profile,
+				strings.Join(c.Profiles, ", "))
+		}
+		c.Profile = profile
+		c.set(values, fmt.Sprintf("%s profile %s", projectPath, profile), filepath.Dir(projectPath))
+	}
+	return nil
 }
 
+// set sets values from source, with paths relative to dir if it's not
+// empty.
+func (c *Config) set(values map[string]any, source, dir string) {
+	for k, v := range values {
+		if s, ok := v.(string); ok && dir != "" && pathKeys[k] && s != "" && !filepath.IsAbs(s) {
+			v = filepath.Join(dir, s)
+		}
+		c.Values[k] = v
+		c.Sources[k] = source
+	}
+}
+
+// setFromEnviron sets values from SOFTBALL_* environment variables, with
+// both a camel case key and a snake case key, e.g. SOFTBALL_RE_MATRIX sets
+// ReMatrix and re_matrix.
 func (config *Config) setFromEnviron() {
 	if config.Values == nil {
 		config.Values = make(map[string]any)
 	}
+	if config.Sources == nil {
+		config.Sources = make(map[string]string)
+	}
 	for _, nv := range os.Environ() {
 		eq := strings.IndexRune(nv, '=')
 		n := nv[0:eq]
-		if strings.HasPrefix(n, "SOFTBALL_") {
+		if strings.HasPrefix(n, "SOFTBALL_") && n != "SOFTBALL_PROFILE" {
 			var k strings.Builder
 			cap := true
 			for _, ch := range n[9:] {
@@ -62,7 +205,10 @@ func (config *Config) setFromEnviron() {
 					k.WriteRune(unicode.ToLower(ch))
 				}
 			}
-			config.Values[k.String()] = nv[eq+1:]
+			for _, key := range []string{k.String(), strings.ToLower(n[9:])} {
+				config.Values[key] = nv[eq+1:]
+				config.Sources[key] = "$" + n
+			}
 		}
 	}
 }
@@ -75,6 +221,28 @@ func (config *Config) Decode(s any) {
 }
 
 func (config *Config) GetString(key string) string {
-	val, _ := config.Values[key].(string)
-	return val
+	switch val := config.Values[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool, int, float64:
+		return fmt.Sprint(val)
+	}
+	return ""
+}
+
+// GetSource returns where the setting for key came from.
+func (config *Config) GetSource(key string) string {
+	return config.Sources[key]
+}
+
+// GetKeys returns the keys of all the settings in order.
+func (config *Config) GetKeys() []string {
+	var keys []string
+	for k := range config.Values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,3 +14,45 @@ func TestEnviron(t *testing.T) {
 	assert := assert.New(t)
 	assert.Equal("/tmp/foo.json", config.GetString("SheetJsonKey"))
 }
+
+func TestProject(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SOFTBALL_PROFILE", "")
+	t.Setenv("SOFTBALL_INNINGS", "6")
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, ProjectFile), []byte(`
+re_matrix: re.csv
+us: pride
+innings: 5
+profiles:
+  16u-2024:
+    us: pride-16u
+    format: csv
+`), 0644))
+	games := filepath.Join(dir, "2024", "spring")
+	assert.NoError(os.MkdirAll(games, 0755))
+
+	c, err := Load("", []string{games})
+	if assert.NoError(err) {
+		assert.Equal(filepath.Join(dir, ProjectFile), c.ProjectPath)
+		assert.Equal(filepath.Join(dir, "re.csv"), c.GetString("re_matrix"))
+		assert.Equal("pride", c.GetString("us"))
+		assert.Equal(c.ProjectPath, c.GetSource("us"))
+		assert.Equal("6", c.GetString("innings"))
+		assert.Equal("$SOFTBALL_INNINGS", c.GetSource("innings"))
+		assert.Equal([]string{"16u-2024"}, c.Profiles)
+		assert.Empty(c.GetString("format"))
+	}
+
+	c, err = Load("16u-2024", []string{games})
+	if assert.NoError(err) {
+		assert.Equal("pride-16u", c.GetString("us"))
+		assert.Equal("csv", c.GetString("format"))
+		assert.Contains(c.GetSource("format"), "profile 16u-2024")
+		assert.Same(c, GetConfig())
+	}
+
+	_, err = Load("18u", []string{games})
+	assert.ErrorContains(err, "16u-2024")
+}
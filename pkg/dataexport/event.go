@@ -49,6 +49,8 @@ type Event struct {
 	Batter               string
 	BatterNumber         string
 	Pitches              string
+	PitchIndex           int
+	EventCount           string
 	NotOutOnPlay         bool
 	Complete             bool
 	Incomplete           bool
@@ -98,7 +100,7 @@ func getEvent(g *game.Game, re stats.RunExpectancy, state *game.State, tournamen
 		Home:                 g.Home.Name,
 		Visitor:              g.Visitor.Name,
 		Tournament:           g.Tournament,
-		Date:                 g.GetDate().Format("2006-01-02"),
+		Date:                 g.GetStateDate(state).Format("2006-01-02"),
 		GameNumber:           parseInt(g.Number),
 		InningNumber:         state.InningNumber,
 		Half:                 string(state.Half),
@@ -122,6 +124,7 @@ func getEvent(g *game.Game, re stats.RunExpectancy, state *game.State, tournamen
 		Batter:               batter.NameOrNumber(),
 		BatterNumber:         batter.Number,
 		Pitches:              string(state.Pitches),
+		PitchIndex:           state.PitchIndex,
 		NotOutOnPlay:         state.NotOutOnPlay,
 		FieldingError:        state.FieldingError.String(),
 		Modifiers:            strings.Join(state.Modifiers, "/"),
@@ -137,6 +140,7 @@ func getEvent(g *game.Game, re stats.RunExpectancy, state *game.State, tournamen
 
 		state: state,
 	}
+	_, event.EventCount, _, _ = state.EventCount()
 	if last := state.LastState; last != nil {
 		event.StartBaseOutCode = getBaseOutCode(last)
 	} else {
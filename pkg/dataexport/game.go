@@ -3,6 +3,7 @@ package dataexport
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/slshen/paperscore/pkg/boxscore"
 	"github.com/slshen/paperscore/pkg/dataframe"
@@ -13,6 +14,7 @@ type Game struct {
 	GameID        string
 	GameNumber    string
 	GameDate      string
+	ResumedDates  string
 	GameFile      string
 	Tournament    string
 	TournamentID  string
@@ -37,6 +39,7 @@ func newGame(g *game.Game, tournamentID string) (*Game, error) {
 		GameID:        getGameID(g),
 		GameNumber:    g.Number,
 		GameDate:      g.GetDate().Format("2006-01-02"),
+		ResumedDates:  getResumedDates(g),
 		GameFile:      fmt.Sprintf("%s/%s", filepath.Base(filepath.Dir(g.File.Path)), filepath.Base(g.File.Path)),
 		Tournament:    g.Tournament,
 		TournamentID:  tournamentID,
@@ -59,3 +62,11 @@ func (games Games) GetData() *dataframe.Data {
 	}
 	return dat
 }
+
+func getResumedDates(g *game.Game) string {
+	var dates []string
+	for _, d := range g.GetDates()[1:] {
+		dates = append(dates, d.Format("2006-01-02"))
+	}
+	return strings.Join(dates, " ")
+}
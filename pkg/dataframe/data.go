@@ -3,6 +3,7 @@ package dataframe
 import (
 	"encoding/csv"
 	"fmt"
+	"html"
 	"io"
 	"reflect"
 	"sort"
@@ -397,3 +398,38 @@ func (dat *Data) RenderMarkdownString() string {
 	_ = dat.RenderMarkdown(s)
 	return s.String()
 }
+
+func (dat *Data) RenderHTML(w io.Writer) error {
+	if dat.Name != "" {
+		fmt.Fprintf(w, "<h2>%s</h2>\n", html.EscapeString(dat.Name))
+	}
+	fmt.Fprintln(w, "<table>")
+	fmt.Fprint(w, "<tr>")
+	var hasSummary bool
+	for _, col := range dat.Columns {
+		hasSummary = hasSummary || col.Summary != None
+		fmt.Fprintf(w, "<th>%s</th>", html.EscapeString(col.Name))
+	}
+	fmt.Fprintln(w, "</tr>")
+	dat.RApply(func(row int) {
+		fmt.Fprint(w, "<tr>")
+		for _, col := range dat.Columns {
+			v := strings.TrimSpace(fmt.Sprintf(col.GetFormat(), col.GetValue(row)))
+			fmt.Fprintf(w, "<td>%s</td>", html.EscapeString(v))
+		}
+		fmt.Fprintln(w, "</tr>")
+	})
+	if hasSummary {
+		fmt.Fprint(w, "<tr>")
+		for _, col := range dat.Columns {
+			v := ""
+			if col.Summary != None {
+				v = strings.TrimSpace(fmt.Sprintf(col.GetSummaryFormat(), col.GetSummary()))
+			}
+			fmt.Fprintf(w, "<td>%s</td>", html.EscapeString(v))
+		}
+		fmt.Fprintln(w, "</tr>")
+	}
+	fmt.Fprintln(w, "</table>")
+	return nil
+}
@@ -29,6 +29,10 @@ func TestString(t *testing.T) {
 	s.Reset()
 	assert.NoError(dat.RenderMarkdown(s))
 	fmt.Println(s.String())
+	s.Reset()
+	assert.NoError(dat.RenderHTML(s))
+	assert.Contains(s.String(), "<td>Thomas</td>")
+	assert.Contains(s.String(), "<td>52</td>")
 	// t.Fail()
 }
 
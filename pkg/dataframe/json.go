@@ -6,6 +6,9 @@ import (
 
 func (dat *Data) MarshalJSON() ([]byte, error) {
 	m := map[string]interface{}{}
+	if dat.Name != "" {
+		m["name"] = dat.Name
+	}
 	cols := []interface{}{}
 	for _, col := range dat.Columns {
 		cols = append(cols, map[string]interface{}{
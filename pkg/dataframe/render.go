@@ -0,0 +1,125 @@
+package dataframe
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format for data.
+type Format string
+
+const (
+	TextFormat     = Format("text")
+	CSVFormat      = Format("csv")
+	JSONFormat     = Format("json")
+	MarkdownFormat = Format("markdown")
+	HTMLFormat     = Format("html")
+	YAMLFormat     = Format("yaml")
+)
+
+var Formats = []Format{TextFormat, CSVFormat, JSONFormat, MarkdownFormat, HTMLFormat, YAMLFormat}
+
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "", "txt":
+		return TextFormat, nil
+	case "md":
+		return MarkdownFormat, nil
+	case "yml":
+		return YAMLFormat, nil
+	}
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(s) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %s, use one of %s", s, FormatNames())
+}
+
+// FormatNames returns the names of the formats, e.g. for flag help.
+func FormatNames() string {
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Render writes tables to w.  In JSON and YAML more than one table is
+// written as a single list of tables.  In CSV each table is its own
+// section, separated by a blank line and starting with a row holding
+// the table name if it has one.
+func Render(w io.Writer, format Format, tables ...*Data) error {
+	switch format {
+	case JSONFormat, YAMLFormat:
+		var v any = tables
+		if len(tables) == 1 {
+			v = tables[0]
+		}
+		dat, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		if format == YAMLFormat {
+			var doc any
+			if err := json.Unmarshal(dat, &doc); err != nil {
+				return err
+			}
+			if dat, err = yaml.Marshal(doc); err != nil {
+				return err
+			}
+		} else {
+			dat = append(dat, '\n')
+		}
+		_, err = w.Write(dat)
+		return err
+	}
+	for i, dat := range tables {
+		var err error
+		switch format {
+		case TextFormat:
+			_, err = fmt.Fprintln(w, dat)
+		case CSVFormat:
+			if len(tables) > 1 {
+				if i > 0 {
+					fmt.Fprintln(w)
+				}
+				if dat.Name != "" {
+					cw := csv.NewWriter(w)
+					_ = cw.Write([]string{dat.Name})
+					cw.Flush()
+				}
+			}
+			err = dat.RenderCSV(w, true)
+		case MarkdownFormat:
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			err = dat.RenderMarkdown(w)
+		case HTMLFormat:
+			err = dat.RenderHTML(w)
+		default:
+			err = fmt.Errorf("unknown format %s", format)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Extension returns the file name extension for the format.
+func (f Format) Extension() string {
+	switch f {
+	case TextFormat:
+		return ".txt"
+	case MarkdownFormat:
+		return ".md"
+	}
+	return "." + string(f)
+}
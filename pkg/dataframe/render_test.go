@@ -0,0 +1,58 @@
+package dataframe
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+	people := &Data{
+		Name: "People",
+		Columns: []*Column{
+			{Name: "Name", Values: []string{"George", "Thomas"}},
+			{Name: "Age", Values: []int{52, 48}},
+		},
+	}
+	pets := &Data{
+		Name: "Pets, etc",
+		Columns: []*Column{
+			{Name: "Name", Values: []string{"Rex"}},
+		},
+	}
+	render := func(format Format, tables ...*Data) string {
+		s := &strings.Builder{}
+		assert.NoError(Render(s, format, tables...))
+		return s.String()
+	}
+
+	assert.Equal(people.String()+"\n", render(TextFormat, people))
+	assert.Equal("Name,Age\nGeorge,52\nThomas,48\n", render(CSVFormat, people))
+	assert.Equal("People\nName,Age\nGeorge,52\nThomas,48\n\n\"Pets, etc\"\nName\nRex\n",
+		render(CSVFormat, people, pets))
+
+	var doc []map[string]any
+	assert.NoError(json.Unmarshal([]byte(render(JSONFormat, people, pets)), &doc))
+	if assert.Len(doc, 2) {
+		assert.Equal("People", doc[0]["name"])
+		assert.Len(doc[1]["rowData"], 1)
+	}
+	var one map[string]any
+	assert.NoError(yaml.Unmarshal([]byte(render(YAMLFormat, people)), &one))
+	assert.Len(one["rowData"], 2)
+
+	assert.Contains(render(MarkdownFormat, people, pets), "# Pets, etc")
+	assert.Contains(render(HTMLFormat, people, pets), "<h2>People</h2>")
+
+	for _, s := range []string{"", "md", "JSON", "yaml"} {
+		_, err := ParseFormat(s)
+		assert.NoError(err, s)
+	}
+	_, err := ParseFormat("pdf")
+	assert.Error(err)
+	assert.Equal(".md", MarkdownFormat.Extension())
+}
@@ -84,7 +84,7 @@ func parseAdvance(play gamefile.Play, s string) (*Advance, error) {
 		a.PassedBall = true
 	case m[4] != "":
 		var err error
-		a.FieldingError, err = parseFieldingError(play, m[4])
+		a.FieldingError, err = parseFieldingError(play.GetPos(), m[4])
 		if err != nil {
 			return nil, err
 		}
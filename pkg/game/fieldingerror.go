@@ -19,12 +19,12 @@ var FielderNames = []string{
 
 var NoError = FieldingError{}
 
-func parseFieldingError(play gamefile.Play, s string) (FieldingError, error) {
+func parseFieldingError(pos gamefile.Position, s string) (FieldingError, error) {
 	if len(s) < 2 || s[0] != 'E' || (len(s) > 2 && s[2] != '/') {
-		return FieldingError{}, NewError("illegal error code %s", play.GetPos(), s)
+		return FieldingError{}, NewError("illegal error code %s", pos, s)
 	}
 	if s[1] < '1' || s[1] > '9' {
-		return FieldingError{}, NewError("illegal fielder %c in error code %s", play.GetPos(), s[1], s)
+		return FieldingError{}, NewError("illegal fielder %c in error code %s", pos, s[1], s)
 	}
 	fe := FieldingError{
 		Fielder: int(s[1] - '0'),
@@ -50,3 +50,11 @@ func (fe FieldingError) String() string {
 	}
 	return s.String()
 }
+
+// ScorerError is an error the official scorer charged on a play, either
+// naming the player for an error already on the play or adding one.
+type ScorerError struct {
+	FieldingError `yaml:",inline"`
+	Player        PlayerID `yaml:",omitempty"`
+	Added         bool     `yaml:",omitempty"`
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -17,22 +18,37 @@ type Score struct {
 	Visitor int `json:"visitor"`
 }
 
+type GameEnding string
+
+const (
+	RegulationEnding = GameEnding("")
+	Forfeit          = GameEnding("forfeit")
+	RunRule          = GameEnding("run-rule")
+	TimeLimit        = GameEnding("time-limit")
+	WalkOff          = GameEnding("walk-off")
+)
+
 type Game struct {
 	File          *gamefile.File `yaml:"-"`
 	ID            string
 	Home, Visitor *Team
 	Final         Score
+	Ending        GameEnding `yaml:",omitempty"`
 	League        string
 	Tournament    string
 	Season        string
 	Date          string
 	Number        string
+	Continues     string           `yaml:",omitempty"`
+	Resumed       []*gamefile.File `yaml:"-"`
 
 	visitorStates []*State
 	homeStates    []*State
 	states        []*State
 	altStates     altStatesMap
+	scoreChecks   []*ScoreCheck
 	date          time.Time
+	resumedDates  []time.Time
 }
 
 type altStatesMap map[*State]*State
@@ -56,6 +72,14 @@ func globExpand(paths []string) ([]string, error) {
 }
 
 func ReadGames(fileOrDirs []string) ([]*Game, error) {
+	games, err := readGames(fileOrDirs)
+	if err != nil {
+		return nil, err
+	}
+	return stitchGames(games)
+}
+
+func readGames(fileOrDirs []string) ([]*Game, error) {
 	var games []*Game
 	fileOrDirs, err := globExpand(fileOrDirs)
 	if err != nil {
@@ -68,7 +92,7 @@ func ReadGames(fileOrDirs []string) ([]*Game, error) {
 			return nil, err
 		}
 		if stat.IsDir() {
-			dirGames, err := ReadGamesDir(fileOrDir)
+			dirGames, err := readGamesDir(fileOrDir)
 			if err != nil {
 				return nil, err
 			}
@@ -79,7 +103,7 @@ func ReadGames(fileOrDirs []string) ([]*Game, error) {
 			}
 			for _, ent := range ents {
 				if ent.IsDir() {
-					moreGames, err := ReadGames([]string{filepath.Join(fileOrDir, ent.Name())})
+					moreGames, err := readGames([]string{filepath.Join(fileOrDir, ent.Name())})
 					if err != nil {
 						return nil, err
 					}
@@ -88,7 +112,7 @@ func ReadGames(fileOrDirs []string) ([]*Game, error) {
 			}
 			continue
 		}
-		fileGames, err := ReadGameFiles([]string{fileOrDir})
+		fileGames, err := readGameFiles([]string{fileOrDir})
 		if err != nil {
 			return nil, err
 		}
@@ -98,6 +122,14 @@ func ReadGames(fileOrDirs []string) ([]*Game, error) {
 }
 
 func ReadGamesDir(dir string) ([]*Game, error) {
+	games, err := readGamesDir(dir)
+	if err != nil {
+		return games, err
+	}
+	return stitchGames(games)
+}
+
+func readGamesDir(dir string) ([]*Game, error) {
 	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
 	if err != nil {
 		return nil, err
@@ -113,17 +145,26 @@ func ReadGamesDir(dir string) ([]*Game, error) {
 			gameFiles = append(gameFiles, f)
 		}
 	}
-	return ReadGameFiles(gameFiles)
+	return readGameFiles(gameFiles)
+}
+
+func ReadGameFiles(paths []string) ([]*Game, error) {
+	games, errs := readGameFiles(paths)
+	games, err := stitchGames(games)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	return games, errs
 }
 
-func ReadGameFiles(paths []string) (games []*Game, errs error) {
+func readGameFiles(paths []string) (games []*Game, errs error) {
 	paths, err := globExpand(paths)
 	if err != nil {
 		return nil, err
 	}
 	sort.Strings(paths)
 	for _, path := range paths {
-		g, err := ReadGameFile(path)
+		g, err := readGameFile(path, false)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 		}
@@ -132,7 +173,16 @@ func ReadGameFiles(paths []string) (games []*Game, errs error) {
 	return
 }
 
+// ReadGameFile reads a game.  A game that continues another game has
+// the states of its own plays, as for NewGame.
 func ReadGameFile(path string) (*Game, error) {
+	return readGameFile(path, true)
+}
+
+// readGameFile reads a game.  Unless standalone is set, a game that
+// continues another game has no states until it's stitched onto the
+// game it continues, which checks its plays.
+func readGameFile(path string, standalone bool) (*Game, error) {
 	var (
 		gf  *gamefile.File
 		err error
@@ -145,10 +195,17 @@ func ReadGameFile(path string) (*Game, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewGame(gf)
+	return newGame(gf, standalone)
 }
 
+// NewGame creates a game from a file.  A game that continues another game
+// has the states of just its own plays, from an unknown score and
+// situation, until it's stitched onto the game it continues.
 func NewGame(gf *gamefile.File) (*Game, error) {
+	return newGame(gf, true)
+}
+
+func newGame(gf *gamefile.File, standalone bool) (*Game, error) {
 	g := &Game{
 		File:       gf,
 		Tournament: gf.Properties["tournament"],
@@ -156,6 +213,7 @@ func NewGame(gf *gamefile.File) (*Game, error) {
 		League:     gf.Properties["league"],
 		Number:     gf.Properties["game"],
 		Date:       gf.Properties["date"],
+		Continues:  gf.Properties["continues"],
 		altStates:  make(altStatesMap),
 	}
 	var errs error
@@ -181,12 +239,76 @@ func NewGame(gf *gamefile.File) (*Game, error) {
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	}
-	if err := g.generateStates(); err != nil {
-		errs = multierror.Append(errs, err)
+	if g.Continues == "" || standalone {
+		if err := g.generateStates(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
 	return g, errs
 }
 
+// stitchGames resumes suspended games with the games that continue them,
+// in the order they were played, wherever they are in games.
+func stitchGames(games []*Game) ([]*Game, error) {
+	var (
+		res   []*Game
+		parts []*Game
+		errs  error
+	)
+	byID := map[string]*Game{}
+	for _, g := range games {
+		if g != nil && g.Continues != "" {
+			parts = append(parts, g)
+			continue
+		}
+		if g != nil {
+			byID[g.ID] = g
+		}
+		res = append(res, g)
+	}
+	sort.SliceStable(parts, func(i, j int) bool {
+		if !parts[i].date.Equal(parts[j].date) {
+			return parts[i].date.Before(parts[j].date)
+		}
+		return parts[i].ID < parts[j].ID
+	})
+	// a part can continue another part, so stitch until no more can be
+	for len(parts) > 0 {
+		var pending []*Game
+		for _, part := range parts {
+			suspended := byID[part.Continues]
+			if suspended == nil {
+				pending = append(pending, part)
+				continue
+			}
+			if err := suspended.resume(part); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+			byID[part.ID] = suspended
+		}
+		if len(pending) == len(parts) {
+			for _, part := range pending {
+				errs = multierror.Append(errs, fmt.Errorf("%s: game %s continues game %s which was not read",
+					part.File.PropertyPos["continues"], part.ID, part.Continues))
+			}
+			break
+		}
+		parts = pending
+	}
+	return res, errs
+}
+
+func (g *Game) resume(part *Game) error {
+	if part.Home.Name != g.Home.Name || part.Visitor.Name != g.Visitor.Name {
+		return fmt.Errorf("%s: game %s between %s and %s cannot continue game %s between %s and %s",
+			part.File.PropertyPos["continues"], part.ID, part.Visitor.Name, part.Home.Name,
+			g.ID, g.Visitor.Name, g.Home.Name)
+	}
+	g.Resumed = append(g.Resumed, part.File)
+	g.resumedDates = append(g.resumedDates, part.date)
+	return g.generateStates()
+}
+
 func (g *Game) GetPlayer(playerID PlayerID) *Player {
 	p := g.Home.Players[playerID]
 	if p == nil {
@@ -209,22 +331,20 @@ func (g *Game) GetVisitorStates() []*State {
 
 func (g *Game) generateStates() (errs error) {
 	var err error
+	g.Final = Score{}
+	g.Ending = RegulationEnding
+	g.altStates = make(altStatesMap)
+	g.scoreChecks = nil
 	g.visitorStates, err = g.runEvents(g.Visitor, g.Home, Top,
-		g.File.VisitorEvents)
+		g.getEvents(Top), &g.Final.Visitor)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	}
-	if len(g.visitorStates) > 0 {
-		g.Final.Visitor = g.visitorStates[len(g.visitorStates)-1].Score
-	}
 	g.homeStates, err = g.runEvents(g.Home, g.Visitor, Bottom,
-		g.File.HomeEvents)
+		g.getEvents(Bottom), &g.Final.Home)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	}
-	if len(g.homeStates) > 0 {
-		g.Final.Home = g.homeStates[len(g.homeStates)-1].Score
-	}
 	g.states = make([]*State, 0, len(g.visitorStates)+len(g.homeStates))
 	half := Top
 	for i, j := 0, 0; i < len(g.visitorStates) || j < len(g.homeStates); {
@@ -254,11 +374,26 @@ func (g *Game) generateStates() (errs error) {
 	return
 }
 
-func (g *Game) runEvents(battingTeam, fieldingTeam *Team, half Half, events []*gamefile.Event) (states []*State, errs error) {
+// getEvents returns the events of a team, including the events of
+// resumed games.
+func (g *Game) getEvents(half Half) []*gamefile.Event {
+	var events []*gamefile.Event
+	for _, f := range append([]*gamefile.File{g.File}, g.Resumed...) {
+		if half == Top {
+			events = append(events, f.VisitorEvents...)
+		} else {
+			events = append(events, f.HomeEvents...)
+		}
+	}
+	return events
+}
+
+func (g *Game) runEvents(battingTeam, fieldingTeam *Team, half Half, events []*gamefile.Event, final *int) (states []*State, errs error) {
 	if events == nil {
 		return
 	}
 	m := newGameMachine(battingTeam, fieldingTeam)
+	m.resumed = g.Continues != ""
 	lastState := &State{
 		InningNumber: 1,
 		Half:         half,
@@ -307,6 +442,16 @@ func (g *Game) runEvents(battingTeam, fieldingTeam *Team, half Half, events []*g
 			}
 		}
 	}
+	if len(states) > 0 {
+		*final = states[len(states)-1].Score
+	}
+	if m.final {
+		*final = m.finalScore
+		if m.ending != RegulationEnding {
+			g.Ending = m.ending
+		}
+	}
+	g.scoreChecks = append(g.scoreChecks, m.scoreChecks...)
 	return
 }
 
@@ -318,6 +463,30 @@ func (g *Game) GetDate() time.Time {
 	return g.date
 }
 
+// GetDates returns the date the game started followed by the dates it
+// was resumed.
+func (g *Game) GetDates() []time.Time {
+	return append([]time.Time{g.date}, g.resumedDates...)
+}
+
+func (g *Game) GetResumedDates() []string {
+	var dates []string
+	for _, f := range g.Resumed {
+		dates = append(dates, f.Properties["date"])
+	}
+	return dates
+}
+
+// GetStateDate returns the date the play for state happened.
+func (g *Game) GetStateDate(state *State) time.Time {
+	for i, f := range g.Resumed {
+		if state.Pos.Filename == f.Path {
+			return g.resumedDates[i]
+		}
+	}
+	return g.date
+}
+
 func (g *Game) GetTournament() string {
 	if g.Tournament != "" {
 		return g.Tournament
@@ -2,9 +2,11 @@ package game
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/slshen/paperscore/pkg/gamefile"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 )
@@ -53,3 +55,118 @@ func TestAlternativeStates(t *testing.T) {
 		assert.Equal("43/G4", alt.PlayCode)
 	}
 }
+
+func TestContinues(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "20220521-1.gm"), []byte(`date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 9
+1 1 X S8/G6 B-1
+2 2 X 63/G6 1-2
+homeplays
+pitching 8
+1 1 X 63/G6
+`), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "20220522-1.gm"), []byte(`date: 5/22/22
+game: 1
+visitor: a
+home: b
+continues: 20220521-1
+---
+visitorplays
+3 3 X S8/G6 B-1 2-H
+4 4 X 63/G6
+final 1
+homeplays
+2 2 X 63/G6
+3 3 X 63/G6
+final 0
+`), 0644))
+	games, err := ReadGames([]string{dir})
+	if !assert.NoError(err) || !assert.Len(games, 1) {
+		return
+	}
+	g := games[0]
+	assert.Equal("20220521-1", g.ID)
+	assert.Len(g.GetDates(), 2)
+	assert.Equal([]string{"5/22/22"}, g.GetResumedDates())
+	assert.Equal(Score{Visitor: 1}, g.Final)
+	states := g.GetVisitorStates()
+	if assert.Len(states, 4) {
+		assert.Equal(PlayerID("9"), states[2].Pitcher)
+		assert.Equal(2, states[3].Outs)
+		assert.Equal(21, g.GetStateDate(states[1]).Day())
+		assert.Equal(22, g.GetStateDate(states[2]).Day())
+	}
+}
+
+func TestContinuesOutOfOrder(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	suspendedPath := filepath.Join(dir, "20220521-1.gm")
+	assert.NoError(os.WriteFile(suspendedPath, []byte(`date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X S8/G6 B-1
+2 2 X 63/G6 1-2
+`), 0644))
+	partPath := filepath.Join(dir, "20220522-1.gm")
+	assert.NoError(os.WriteFile(partPath, []byte(`date: 5/22/22
+game: 1
+visitor: a
+home: b
+continues: 20220521-1
+---
+visitorplays
+3 3 X S8/G6 B-1 2-H
+4 4 X 63/G6 1-2
+5 5 X 63/G6
+score 1
+final 1
+homeplays
+1 1 X 63/G6
+final 0
+`), 0644))
+
+	// read alone, the continuation has the states of its own plays
+	part, err := ReadGameFile(partPath)
+	if assert.NoError(err) {
+		states := part.GetVisitorStates()
+		if assert.Len(states, 3) {
+			assert.Equal([]PlayerID{unknownRunner}, states[0].ScoringRunners)
+			assert.Equal(PlayerID("3"), states[1].Runners[1])
+		}
+	}
+	suspended, err := ReadGameFile(suspendedPath)
+	if !assert.NoError(err) {
+		return
+	}
+	games, err := stitchGames([]*Game{part, suspended})
+	if assert.NoError(err) && assert.Len(games, 1) {
+		assert.Equal(Score{Visitor: 1}, games[0].Final)
+		assert.Len(games[0].GetVisitorStates(), 5)
+	}
+
+	// errors in the continuation's own plays are found when read alone
+	gf, err := gamefile.ParseString(partPath, `date: 5/22/22
+game: 1
+visitor: a
+home: b
+continues: 20220521-1
+---
+visitorplays
+3 3 BBBBB W
+`)
+	if assert.NoError(err) {
+		_, err = NewGame(gf)
+		assert.ErrorContains(err, "4 balls")
+	}
+}
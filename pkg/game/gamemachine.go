@@ -16,7 +16,23 @@ type gameMachine struct {
 	pitcher      PlayerID
 	basePutOuts  map[string]bool
 	final        bool
+	finalScore   int
+	ending       GameEnding
+	scoreChecks  []*ScoreCheck
 	modifiers    Modifiers
+	// resumed is set when the plays continue a suspended game without
+	// the plays before them, so the score, and the runners and outs of
+	// the first half inning, are unknown
+	resumed bool
+}
+
+// unknownRunner is a runner on base when a suspended game resumed.
+const unknownRunner = PlayerID("?")
+
+// isSituationUnknown returns true if the runners and outs at state are
+// unknown because it's in the half inning a suspended game resumed in.
+func (m *gameMachine) isSituationUnknown(state *State) bool {
+	return m.resumed && state.InningNumber == 1
 }
 
 func newGameMachine(battingTeam, fieldingTeam *Team) *gameMachine {
@@ -81,6 +97,7 @@ func (m *gameMachine) handleAlternative(alt *gamefile.Alternative, lastState *St
 		state.AlternativeCredits = append(state.AlternativeCredits, player)
 	}
 	err := m.handlePlay(alt, state)
+	state.PitchIndex = len(state.Pitches) - 1
 	return state, err
 }
 
@@ -101,6 +118,8 @@ func (m *gameMachine) handleActualPlay(play *gamefile.ActualPlay, lastState *Sta
 		return nil, NewError("no batter for %s", play.GetPos(), play.GetCode())
 	}
 	err := m.handlePlay(play, state)
+	// running plays recorded on this line happened on the last pitch
+	state.PitchIndex = len(state.Pitches) - 1
 	for _, after := range play.Afters {
 		// handle subs for runners on base
 		var runnerEnter, runnerExit PlayerID
@@ -119,6 +138,10 @@ func (m *gameMachine) handleActualPlay(play *gamefile.ActualPlay, lastState *Sta
 		for i := range state.Runners {
 			if state.Runners[i] == runnerExit {
 				state.Runners[i] = runnerEnter
+				if state.RunnerSubs == nil {
+					state.RunnerSubs = map[PlayerID]PlayerID{}
+				}
+				state.RunnerSubs[runnerEnter] = runnerExit
 				break
 			}
 		}
@@ -193,6 +216,16 @@ func (m *gameMachine) handlePlay(play gamefile.Play, state *State) error {
 func (m *gameMachine) parseAdvances(play gamefile.Play, state *State) error {
 	var runners [3]PlayerID
 	if state.LastState != nil {
+		if m.isSituationUnknown(state) {
+			// runners who were on base when the game resumed
+			for _, as := range play.GetAdvances() {
+				if adv, err := parseAdvance(play, as); err == nil && adv.From != "B" {
+					if number := BaseNumber[adv.From]; state.LastState.Runners[number] == "" {
+						state.LastState.Runners[number] = unknownRunner
+					}
+				}
+			}
+		}
 		runners = state.LastState.Runners
 	}
 	var err error
@@ -234,23 +267,26 @@ func (m *gameMachine) handleSpecialEvent(event *gamefile.Event, state *State) (*
 		return nil, NewError("cannot sub %s for %s because %s is not in the field", event.Pos, enter, exit, exit)
 	}
 	if event.Score != "" {
+		score, err := strconv.Atoi(event.Score)
+		m.addScoreCheck(event, state, score, err == nil)
+		if m.resumed && err == nil {
+			// the runs before the game resumed are unknown
+			return nil, nil
+		}
 		if state.Outs != 3 {
 			return nil, NewError("the inning with %d outs has not ended after %s",
 				event.Pos, state.Outs, state.PlayCode)
 		}
-		score, err := strconv.Atoi(event.Score)
 		if err != nil || state.Score != score {
 			return nil, NewError("in inning %d # runs is %d not %s", event.Pos,
 				state.InningNumber, state.Score, event.Score)
 		}
 	}
 	if event.Final != "" {
-		score, err := strconv.Atoi(event.Final)
-		if err != nil || state.Score != score {
-			return nil, NewError("in inning %d final score is %d not %s", event.Pos,
-				state.InningNumber, state.Score, event.Score)
-		}
-		m.final = true
+		return nil, m.handleFinal(event, state)
+	}
+	if event.Err != "" {
+		return nil, m.handleScorerError(event, state)
 	}
 	if event.RAdjRunner != "" {
 		runner := m.battingTeam.parsePlayerID(event.RAdjRunner.String())
@@ -277,6 +313,94 @@ func (m *gameMachine) handleSpecialEvent(event *gamefile.Event, state *State) (*
 	return nil, nil
 }
 
+func (m *gameMachine) addScoreCheck(event *gamefile.Event, state *State, score int, valid bool) {
+	check := &ScoreCheck{
+		Pos:          event.Pos,
+		Half:         state.Half,
+		InningNumber: state.InningNumber,
+		Final:        event.Final != "",
+		Ending:       GameEnding(event.FinalReason),
+		Recorded:     score,
+		Valid:        valid,
+		Computed:     state.Score,
+	}
+	m.scoreChecks = append(m.scoreChecks, check)
+}
+
+func (m *gameMachine) handleFinal(event *gamefile.Event, state *State) error {
+	score, err := strconv.Atoi(event.Final)
+	m.addScoreCheck(event, state, score, err == nil)
+	if err != nil {
+		return NewError("final score %s is not a number", event.Pos, event.Final)
+	}
+	m.final = true
+	m.finalScore = state.Score
+	m.ending = GameEnding(event.FinalReason)
+	switch m.ending {
+	case RegulationEnding:
+	case RunRule, TimeLimit:
+		// the game can end before the inning is over
+		if state.Outs != 3 && !state.Complete {
+			state.Incomplete = true
+		}
+	case WalkOff:
+		if state.Top() {
+			return NewError("only the home team can walk off", event.Pos)
+		}
+		if m.resumed {
+			// the runs before the game resumed are unknown
+			return nil
+		}
+		if score > state.Score {
+			return NewError("in inning %d walk off score %d is more than the %d runs scored", event.Pos,
+				state.InningNumber, score, state.Score)
+		}
+		// runners who crossed the plate after the winning run do not score
+		removed := state.Score - score
+		if removed > len(state.ScoringRunners) {
+			return NewError("in inning %d walk off score %d removes runs scored before the last play", event.Pos,
+				state.InningNumber, score)
+		}
+		state.ScoringRunners = state.ScoringRunners[0 : len(state.ScoringRunners)-removed]
+		state.Score = score
+		m.finalScore = score
+		return nil
+	case Forfeit:
+		// the awarded score replaces the score on the field
+		m.finalScore = score
+		return nil
+	default:
+		return NewError("unknown game ending %s", event.Pos, event.FinalReason)
+	}
+	if state.Score != score && !m.resumed {
+		return NewError("in inning %d final score is %d not %s", event.Pos,
+			state.InningNumber, state.Score, event.Final)
+	}
+	return nil
+}
+
+func (m *gameMachine) handleScorerError(event *gamefile.Event, state *State) error {
+	if state.PlayCode == "" {
+		return NewError("err must follow a play", event.Pos)
+	}
+	fe, err := parseFieldingError(event.Pos, event.Err)
+	if err != nil {
+		return err
+	}
+	se := &ScorerError{
+		FieldingError: fe,
+		Added:         !state.HasFieldingError(fe.Fielder),
+	}
+	if event.ErrPlayer != "" {
+		se.Player = m.fieldingTeam.parsePlayerID(event.ErrPlayer)
+		if se.Player == "" {
+			return NewError("no player %s for err", event.Pos, event.ErrPlayer)
+		}
+	}
+	state.ScorerErrors = append(state.ScorerErrors, se)
+	return nil
+}
+
 func (m *gameMachine) impliedAdvance(play gamefile.Play, state *State, code string) *Advance {
 	impliedAdvance, err := parseAdvance(play, code)
 	if err != nil {
@@ -304,6 +428,9 @@ func (m *gameMachine) handlePlayCode(play gamefile.Play, state *State) error {
 			Type:     FlyOut,
 			Fielders: pp.getFielders(0),
 		}
+		if m.modifiers.Contains(InfieldFlyRule) {
+			state.Play.Type = InfieldFly
+		}
 		if m.modifiers.Contains(SacrificeFly) {
 			// verify that we're only scoring a SacrificeFly if a runner scores
 			ok := false
@@ -406,6 +533,13 @@ func (m *gameMachine) handlePlayCode(play gamefile.Play, state *State) error {
 		}
 		m.impliedAdvance(play, state, "B-1")
 		state.Complete = true
+	case pp.playIs("IW"):
+		// intentional walks are awarded without pitches, so the count is not checked
+		state.Play = Play{
+			Type: IntentionalWalk,
+		}
+		m.impliedAdvance(play, state, "B-1")
+		state.Complete = true
 	case pp.playIs("W+SB%"):
 		state.Play = Play{
 			Type: Walk,
@@ -485,6 +619,49 @@ func (m *gameMachine) handlePlayCode(play gamefile.Play, state *State) error {
 			Type: WildPitch,
 		}
 		// movement in advances
+	case pp.playIs("IP"):
+		state.Play = Play{
+			Type: IllegalPitch,
+		}
+		if actualPlay, ok := play.(*gamefile.ActualPlay); ok {
+			if !strings.HasSuffix(actualPlay.PitchSequence, "B") {
+				return NewError("IP pitch sequence %s should end with B", play.GetPos(),
+					actualPlay.PitchSequence)
+			}
+		}
+		// every runner is awarded one base
+		if state.LastState != nil {
+			for _, base := range []string{"3", "2", "1"} {
+				runner := state.LastState.Runners[BaseNumber[base]]
+				if runner != "" {
+					adv := m.impliedAdvance(play, state, fmt.Sprintf("%s-%s", base, NextBase[base]))
+					adv.Runner = runner
+				}
+			}
+		}
+	case pp.playIs("BINT"):
+		state.Play = Play{
+			Type:     BatterInterference,
+			Fielders: []int{2},
+		}
+		state.recordOut()
+		state.Complete = true
+	case pp.playIs("RINT%") || pp.playIs("RINT%($)"):
+		if err := m.handleRunnerPutOut(play, state, pp, RunnerInterference); err != nil {
+			return err
+		}
+	case pp.playIs("AP%($)") || pp.playIs("AP%($$)") || pp.playIs("AP%($$$)"):
+		if err := m.handleRunnerPutOut(play, state, pp, AppealOut); err != nil {
+			return err
+		}
+	case pp.playIs("IF$"):
+		// infield fly that was not caught, the batter is still out
+		state.Play = Play{
+			Type:     InfieldFly,
+			Fielders: pp.getFielders(0),
+		}
+		state.recordOut()
+		state.Complete = true
 	case pp.playIs("HP"):
 		state.Play = Play{
 			Type: HitByPitch,
@@ -498,7 +675,7 @@ func (m *gameMachine) handlePlayCode(play gamefile.Play, state *State) error {
 		m.impliedAdvance(play, state, "B-1")
 		state.Complete = true
 	case pp.playIs("E$"):
-		fe, err := parseFieldingError(play, pp.playCode)
+		fe, err := parseFieldingError(play.GetPos(), pp.playCode)
 		if err != nil {
 			return NewError("cannot parse fielding error in %s - %w", play.GetPos(),
 				pp.playCode, err)
@@ -673,6 +850,31 @@ func (m *gameMachine) handlePickedoff(play gamefile.Play, state *State, pp playC
 	return nil
 }
 
+func (m *gameMachine) handleRunnerPutOut(play gamefile.Play, state *State, pp playCodeParser, playType PlayType) error {
+	from := pp.playMatches[0]
+	if from == "H" {
+		return NewError("a runner out at H should be recorded as an out advance on the scoring play", play.GetPos())
+	}
+	if !(from == "1" || from == "2" || from == "3") {
+		return NewError("illegal base %s for runner put out", play.GetPos(), from)
+	}
+	runner, err := state.GetBaseRunner(from)
+	if err != nil {
+		return NewError("cannot put out runner in %s - %w", play.GetPos(), pp.playCode, err)
+	}
+	if state.Advances.From(from) != nil {
+		return NewError("runner on %s who is put out cannot advance", play.GetPos(), from)
+	}
+	state.Play = Play{
+		Type:         playType,
+		Fielders:     pp.getAllFielders(1),
+		PutOutRunner: runner,
+	}
+	state.recordOut()
+	m.putOut(from)
+	return nil
+}
+
 func (m *gameMachine) handleStolenBase(play gamefile.Play, state *State, eventMatches []string) error {
 	if state.LastState == nil {
 		return NewError("cannot steal bases at the start of a half-inning", play.GetPos())
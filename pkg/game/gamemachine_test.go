@@ -20,3 +20,85 @@ func TestCE2(t *testing.T) {
 	assert.NoError(gm.handlePlayCode(play, &state))
 	assert.Equal(state.Type, CatcherInterference)
 }
+
+func TestIllegalPitch(t *testing.T) {
+	assert := assert.New(t)
+	var (
+		gm        gameMachine
+		lastState = &State{Runners: [3]PlayerID{"1", "", "3"}}
+		state     = State{LastState: lastState}
+		play      = &gamefile.ActualPlay{
+			PitchSequence: "CB",
+			Code:          "IP",
+		}
+	)
+	assert.NoError(gm.handlePlay(play, &state))
+	assert.Equal(IllegalPitch, state.Type)
+	assert.False(state.Complete)
+	assert.Equal([3]PlayerID{"", "1", ""}, state.Runners)
+	assert.Equal([]PlayerID{"3"}, state.ScoringRunners)
+	play.PitchSequence = "BC"
+	state = State{LastState: lastState}
+	assert.Error(gm.handlePlay(play, &state))
+}
+
+func TestIntentionalWalk(t *testing.T) {
+	assert := assert.New(t)
+	var (
+		gm    gameMachine
+		state = State{PlateAppearance: PlateAppearance{Batter: "9"}}
+		play  = &gamefile.ActualPlay{
+			PitchSequence: "B",
+			Code:          "IW",
+		}
+	)
+	assert.NoError(gm.handlePlay(play, &state))
+	assert.Equal(IntentionalWalk, state.Type)
+	assert.True(state.Complete)
+	assert.False(state.IsAB())
+	assert.True(state.Play.IsBaseOnBalls())
+	assert.Equal(PlayerID("9"), state.Runners[0])
+}
+
+func TestRunnerPutOut(t *testing.T) {
+	assert := assert.New(t)
+	lastState := &State{Runners: [3]PlayerID{"1", "2", ""}}
+	for _, code := range []string{"AP2(64)", "RINT2"} {
+		var (
+			gm    gameMachine
+			state = State{LastState: lastState}
+			play  = &gamefile.ActualPlay{
+				PitchSequence: "B",
+				Code:          code,
+			}
+		)
+		if !assert.NoError(gm.handlePlay(play, &state), code) {
+			continue
+		}
+		assert.Equal(PlayerID("2"), state.PutOutRunner)
+		assert.Equal(1, state.Outs)
+		assert.False(state.Complete)
+		assert.Equal([3]PlayerID{"1", "", ""}, state.Runners)
+	}
+	var (
+		gm    gameMachine
+		state = State{LastState: lastState}
+	)
+	assert.Error(gm.handlePlay(&gamefile.ActualPlay{Code: "AP3(5)"}, &state))
+}
+
+func TestInfieldFly(t *testing.T) {
+	assert := assert.New(t)
+	for _, code := range []string{"6/IF", "IF6"} {
+		var (
+			gm    gameMachine
+			state State
+			play  = &gamefile.ActualPlay{
+				PitchSequence: "X",
+				Code:          code,
+			}
+		)
+		assert.NoError(gm.handlePlayCode(play, &state), code)
+		assert.Equal(InfieldFly, state.Type, code)
+	}
+}
@@ -14,6 +14,7 @@ const (
 	SacrificeFly           = "SF"
 	Obstruction            = "OBS"
 	GroundedIntoDoublePlay = "GDP"
+	InfieldFlyRule         = "IF"
 )
 
 const (
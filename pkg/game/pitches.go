@@ -1,6 +1,9 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 func (ps Pitches) CountUp(codes ...rune) (count int) {
 	for _, p := range ps {
@@ -51,3 +54,17 @@ func (ps Pitches) Count() (bool, string, int, int) {
 	}
 	return true, fmt.Sprintf("%d-%d", balls, strikes), balls, strikes
 }
+
+// EventPitches returns the pitches thrown before the play at pitchIndex
+// happened. A play on a '.' happened between pitches, so the pitches up to
+// it are included; otherwise the play happened on that pitch, so the
+// returned pitches stop just before it.
+func (ps Pitches) EventPitches(pitchIndex int) Pitches {
+	if pitchIndex < 0 || pitchIndex >= len(ps) {
+		return ""
+	}
+	if ps[pitchIndex] == '.' {
+		return Pitches(strings.TrimRight(string(ps[0:pitchIndex]), "."))
+	}
+	return ps[0:pitchIndex]
+}
@@ -31,3 +31,22 @@ func TestPitches(t *testing.T) {
 	}
 	assert.Equal('X', Pitches("CX").Last())
 }
+
+func TestEventPitches(t *testing.T) {
+	assert := assert.New(t)
+	for _, tc := range []struct {
+		in    string
+		index int
+		count string
+	}{
+		{"", -1, "0-0"},
+		{"B", 0, "0-0"},
+		{"CB", 1, "0-1"},
+		{"B.", 1, "1-0"},
+		{".", 0, "0-0"},
+		{"BSBB", 3, "2-1"},
+	} {
+		_, count, _, _ := Pitches(tc.in).EventPitches(tc.index).Count()
+		assert.Equal(tc.count, count, tc.in)
+	}
+}
@@ -33,6 +33,12 @@ const (
 	StrikeOutCaughtStealing
 	FoulFlyError
 	NoPlay
+	IllegalPitch
+	BatterInterference
+	RunnerInterference
+	AppealOut
+	InfieldFly
+	IntentionalWalk
 )
 
 //go:generate stringer -type=PlayType
@@ -51,6 +57,7 @@ type Play struct {
 	PickedOffRunner      PlayerID      `yaml:",omitempty"`
 	CaughtStealingRunner PlayerID      `yaml:",omitempty"`
 	CaughtStealingBase   string        `yaml:",omitempty"`
+	PutOutRunner         PlayerID      `yaml:",omitempty"` // out on appeal or interference
 	NotOutOnPlay         bool          `yaml:",omitempty"` // not out on CS, POCS due to error
 }
 
@@ -77,7 +84,11 @@ func (p *Play) IsWalk() bool {
 }
 
 func (p *Play) IsBallInPlay() bool {
-	return p.IsHit() || p.Is(ReachedOnError, FieldersChoice, GroundOut, FlyOut, DoublePlay, TriplePlay)
+	return p.IsHit() || p.Is(ReachedOnError, FieldersChoice, GroundOut, FlyOut, DoublePlay, TriplePlay, InfieldFly)
+}
+
+func (p *Play) IsBaseOnBalls() bool {
+	return p.IsWalk() || p.Type == IntentionalWalk
 }
 
 /*func (p *Play) GetRunner(n int) PlayerID {
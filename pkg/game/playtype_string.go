@@ -38,11 +38,17 @@ func _() {
 	_ = x[StrikeOutCaughtStealing-27]
 	_ = x[FoulFlyError-28]
 	_ = x[NoPlay-29]
+	_ = x[IllegalPitch-30]
+	_ = x[BatterInterference-31]
+	_ = x[RunnerInterference-32]
+	_ = x[AppealOut-33]
+	_ = x[InfieldFly-34]
+	_ = x[IntentionalWalk-35]
 }
 
-const _PlayType_name = "SingleDoubleGroundRuleDoubleTripleHomeRunCaughtStealingHitByPitchWalkWalkWildPitchWalkPassedBallWalkPickedOffStolenBasePickedOffCatcherInterferenceReachedOnErrorFieldersChoiceWildPitchPassedBallGroundOutFlyOutDoublePlayTriplePlayStrikeOutStrikeOutPassedBallStrikeOutWildPitchStrikeOutPickedOffStrikeOutStolenBaseStrikeOutCaughtStealingFoulFlyErrorNoPlay"
+const _PlayType_name = "SingleDoubleGroundRuleDoubleTripleHomeRunCaughtStealingHitByPitchWalkWalkWildPitchWalkPassedBallWalkPickedOffStolenBasePickedOffCatcherInterferenceReachedOnErrorFieldersChoiceWildPitchPassedBallGroundOutFlyOutDoublePlayTriplePlayStrikeOutStrikeOutPassedBallStrikeOutWildPitchStrikeOutPickedOffStrikeOutStolenBaseStrikeOutCaughtStealingFoulFlyErrorNoPlayIllegalPitchBatterInterferenceRunnerInterferenceAppealOutInfieldFlyIntentionalWalk"
 
-var _PlayType_index = [...]uint16{0, 6, 12, 28, 34, 41, 55, 65, 69, 82, 96, 109, 119, 128, 147, 161, 175, 184, 194, 203, 209, 219, 229, 238, 257, 275, 293, 312, 335, 347, 353}
+var _PlayType_index = [...]uint16{0, 6, 12, 28, 34, 41, 55, 65, 69, 82, 96, 109, 119, 128, 147, 161, 175, 184, 194, 203, 209, 219, 229, 238, 257, 275, 293, 312, 335, 347, 353, 365, 383, 401, 410, 420, 435}
 
 func (i PlayType) String() string {
 	if i >= PlayType(len(_PlayType_index)-1) {
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ScoreCheck is a score or final line and the score computed from the plays
+// before it.
+type ScoreCheck struct {
+	Pos FileLocation
+	Half
+	InningNumber int
+	Final        bool
+	Ending       GameEnding
+	Recorded     int
+	Valid        bool
+	Computed     int
+}
+
+type InningScore struct {
+	Half
+	InningNumber int
+	Runs         int
+	Score        int
+	ScoringPlays []*State
+	Checks       []*ScoreCheck
+}
+
+func (sc *ScoreCheck) Matches() bool {
+	return sc.Valid && sc.Recorded == sc.Computed
+}
+
+// Explain describes why the recorded score differs from the computed score.
+func (sc *ScoreCheck) Explain() string {
+	switch {
+	case !sc.Valid:
+		return "recorded score is not a number"
+	case sc.Ending == Forfeit:
+		return fmt.Sprintf("forfeit awarded %d runs, %d were scored", sc.Recorded, sc.Computed)
+	case sc.Ending == WalkOff && sc.Recorded < sc.Computed:
+		return fmt.Sprintf("walk off removed %d runs", sc.Computed-sc.Recorded)
+	case sc.Recorded > sc.Computed:
+		return fmt.Sprintf("plays are missing %d runs", sc.Recorded-sc.Computed)
+	case sc.Recorded < sc.Computed:
+		return fmt.Sprintf("plays have %d extra runs", sc.Computed-sc.Recorded)
+	}
+	return ""
+}
+
+func (g *Game) GetScoreChecks() []*ScoreCheck {
+	return g.scoreChecks
+}
+
+// GetInningScores returns the runs computed for each half inning together
+// with the score and final lines recorded for it.
+func (g *Game) GetInningScores() []*InningScore {
+	var (
+		innings []*InningScore
+		inning  *InningScore
+	)
+	get := func(half Half, number int) *InningScore {
+		for _, in := range innings {
+			if in.Half == half && in.InningNumber == number {
+				return in
+			}
+		}
+		in := &InningScore{
+			Half:         half,
+			InningNumber: number,
+		}
+		innings = append(innings, in)
+		return in
+	}
+	for _, state := range g.states {
+		if inning == nil || inning.Half != state.Half || inning.InningNumber != state.InningNumber {
+			inning = get(state.Half, state.InningNumber)
+		}
+		if runs := len(state.ScoringRunners); runs > 0 {
+			inning.Runs += runs
+			inning.ScoringPlays = append(inning.ScoringPlays, state)
+		}
+		inning.Score = state.Score
+	}
+	for _, check := range g.scoreChecks {
+		in := get(check.Half, check.InningNumber)
+		in.Checks = append(in.Checks, check)
+	}
+	sort.SliceStable(innings, func(i, j int) bool {
+		if innings[i].InningNumber != innings[j].InningNumber {
+			return innings[i].InningNumber < innings[j].InningNumber
+		}
+		return innings[i].Half == Top && innings[j].Half == Bottom
+	})
+	return innings
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestGame(t *testing.T, text string) (*Game, error) {
+	gf, err := gamefile.ParseString("20220521-1.gm", text)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return NewGame(gf)
+}
+
+func TestFinalEndings(t *testing.T) {
+	assert := assert.New(t)
+	header := `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X 63/G6
+2 2 X 63/G6
+3 3 X 63/G6
+`
+	g, err := readTestGame(t, header+`final 0
+homeplays
+1 1 X S8/G6 B-1
+2 2 X S8/G6 B-1 1-2
+3 3 X D8/F8 B-2 1-H 2-H
+final 1 walk-off
+`)
+	assert.NoError(err)
+	assert.Equal(WalkOff, g.Ending)
+	assert.Equal(Score{Home: 1, Visitor: 0}, g.Final)
+	last := g.GetHomeStates()[2]
+	assert.Len(last.ScoringRunners, 1)
+	g, err = readTestGame(t, header+`final 7 forfeit
+homeplays
+1 1 X 63/G6
+final 0
+`)
+	assert.NoError(err)
+	assert.Equal(Forfeit, g.Ending)
+	assert.Equal(Score{Home: 0, Visitor: 7}, g.Final)
+	g, err = readTestGame(t, header+`final 0
+homeplays
+1 1 X 43/G4
+2 2 X E6/G6 B-1
+err E6 2
+3 3 B SB2
+final 0 time-limit
+`)
+	assert.NoError(err)
+	states := g.GetHomeStates()
+	if assert.Len(states[1].ScorerErrors, 1) {
+		assert.False(states[1].ScorerErrors[0].Added)
+		assert.Equal(PlayerID("2"), states[1].GetErrorPlayer(states[1].FieldingError))
+	}
+	assert.True(states[2].Incomplete)
+	g, err = readTestGame(t, header+`final 1
+homeplays
+1 1 X 63/G6
+final 0
+`)
+	assert.Error(err)
+	innings := g.GetInningScores()
+	if assert.Len(innings, 2) && assert.Len(innings[0].Checks, 1) {
+		assert.False(innings[0].Checks[0].Matches())
+		assert.Equal("plays are missing 1 runs", innings[0].Checks[0].Explain())
+	}
+}
+
+func TestFinalEndingsYAML(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "20220521-1.yaml")
+	assert.NoError(os.WriteFile(path, []byte(`date: 5/21/22
+game: 1
+visitor: a
+home: b
+visitorplays:
+  - 1,X,63/G6
+  - 2,X,63/G6
+  - 3,X,63/G6
+  - final,0
+homeplays:
+  - 1,X,S8/G6.B-1
+  - 2,B,SB2
+  - final,0,time-limit
+`), 0644))
+	g, err := ReadGameFile(path)
+	if assert.NoError(err) {
+		assert.Equal(TimeLimit, g.Ending)
+		// the game ended during the plate appearance
+		assert.True(g.GetHomeStates()[1].Incomplete)
+	}
+}
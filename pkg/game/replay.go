@@ -0,0 +1,177 @@
+package game
+
+import (
+	"fmt"
+)
+
+// Replay is the rest of a half inning replayed after an alternative
+// play.  The batters come up in the same order as they actually did,
+// and the runner advances of each actual play are applied to the runners
+// on base in the replay.  Plays that cannot be applied, such as a steal
+// by a runner who is no longer on base, are skipped.
+type Replay struct {
+	Alternative *State
+	States      []*State
+	Skipped     []*State
+	// Runs is the runs scored by the alternative and the replayed plays.
+	Runs int
+	// ActualRuns is the runs scored by the actual play and the rest of
+	// the half inning.
+	ActualRuns int
+}
+
+// Complete returns true if the replay reached 3 outs.
+func (r *Replay) Complete() bool {
+	return r.Final().Outs == 3
+}
+
+// Final returns the last state of the replay.
+func (r *Replay) Final() *State {
+	if len(r.States) > 0 {
+		return r.States[len(r.States)-1]
+	}
+	return r.Alternative
+}
+
+// ReplayAlternative replays the half inning of alt with the alternative
+// play in place of the actual play.
+func (g *Game) ReplayAlternative(alt *State) (*Replay, error) {
+	actual := alt.AlternativeFor
+	if actual == nil {
+		return nil, fmt.Errorf("state is not an alternative")
+	}
+	events := g.getEvents(alt.Half)
+	var actualStates []*State
+	if alt.Half == Top {
+		actualStates = g.visitorStates
+	} else {
+		actualStates = g.homeStates
+	}
+	byPos := map[string]*State{}
+	for _, state := range actualStates {
+		byPos[posKey(state.Pos)] = state
+	}
+	start := -1
+	for i, event := range events {
+		if event.Play != nil && posKey(event.Play.Pos) == posKey(actual.Pos) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("cannot find the play for the alternative at %s", actual.Pos)
+	}
+	r := &Replay{
+		Alternative: alt,
+		Runs:        len(alt.ScoringRunners),
+		ActualRuns:  len(actual.ScoringRunners),
+	}
+	var (
+		battingTeam, fieldingTeam = g.Home, g.Visitor
+		last                      = alt
+	)
+	if alt.Half == Top {
+		battingTeam, fieldingTeam = g.Visitor, g.Home
+	}
+	m := newGameMachine(battingTeam, fieldingTeam)
+	for _, event := range events[start+1:] {
+		if event.Play == nil {
+			continue
+		}
+		state := byPos[posKey(event.Play.Pos)]
+		if state == nil || state.InningNumber != actual.InningNumber {
+			break
+		}
+		r.ActualRuns += len(state.ScoringRunners)
+		if last.Outs < 3 {
+			m.pitcher = state.Pitcher
+			replayed := m.replayPlay(state, last)
+			if replayed == nil {
+				r.Skipped = append(r.Skipped, state)
+			} else {
+				r.Runs += len(replayed.ScoringRunners)
+				r.States = append(r.States, replayed)
+				last = replayed
+			}
+		}
+		if state.Outs == 3 {
+			break
+		}
+	}
+	return r, nil
+}
+
+func posKey(pos FileLocation) string {
+	return fmt.Sprintf("%s:%d", pos.Filename, pos.Line)
+}
+
+// replayPlay applies the actual play in state after last, or returns nil
+// if the play cannot be applied.
+func (m *gameMachine) replayPlay(state *State, last *State) *State {
+	play := &replayPlay{
+		pos:  state.Pos,
+		code: state.PlayCode,
+	}
+	forced := false
+	if adv := state.Advances.From("B"); adv != nil && !adv.Out {
+		forced = true
+	}
+	var moved [3]bool
+	for _, code := range state.AdvancesCodes {
+		adv := state.Advances.From(code[0:1])
+		if adv == nil || adv.From == "B" {
+			play.advances = append(play.advances, code)
+			continue
+		}
+		// find the runner in the replay
+		from := ""
+		for i, runner := range last.Runners {
+			if runner == adv.Runner {
+				from = fmt.Sprint(i + 1)
+			}
+		}
+		if from == "" || BaseNumber[adv.To] <= BaseNumber[from] {
+			continue
+		}
+		moved[BaseNumber[from]] = true
+		play.advances = append(play.advances, from+code[1:])
+	}
+	// runners who are forced by the batter but did not move in the actual play
+	for base := 0; forced && base < 3; base++ {
+		if last.Runners[base] == "" {
+			break
+		}
+		if !moved[base] {
+			play.advances = append(play.advances,
+				fmt.Sprintf("%d-%s", base+1, NextBase[fmt.Sprint(base+1)]))
+		}
+	}
+	replayed := m.newState(state.Pos, last)
+	replayed.Batter = state.Batter
+	replayed.Pitches = state.Pitches
+	replayed.PlateAppearance.Number = state.PlateAppearance.Number
+	if err := m.handlePlay(play, replayed); err != nil {
+		return nil
+	}
+	return replayed
+}
+
+// replayPlay is an actual play with its advances rewritten for the
+// runners on base in a replay.
+type replayPlay struct {
+	pos      FileLocation
+	code     string
+	advances []string
+}
+
+func (p *replayPlay) GetPos() FileLocation {
+	return p.pos
+}
+
+func (p *replayPlay) GetCode() string {
+	return p.code
+}
+
+func (p *replayPlay) GetAdvances() []string {
+	return p.advances
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayAlternative(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X E6/G6 B-1
+  alt 63/G6
+2 2 X S8/G6 1-2
+3 3 B SB3
+... X D8/F8 B-2 3-H 1-H
+4 4 SSS K
+5 5 X 63/G6
+6 6 X 8/F8
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	alt := g.GetAlternativeState(g.GetStates()[0])
+	if !assert.NotNil(alt) {
+		return
+	}
+	r, err := g.ReplayAlternative(alt)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(2, r.ActualRuns)
+	assert.Equal(1, r.Runs)
+	assert.True(r.Complete())
+	if assert.Len(r.Skipped, 1) {
+		assert.Equal("SB3", r.Skipped[0].PlayCode)
+	}
+	assert.Len(r.States, 4)
+	_, err = g.ReplayAlternative(g.GetStates()[0])
+	assert.Error(err)
+}
@@ -29,12 +29,16 @@ type State struct {
 	Score   int
 	Pitcher PlayerID
 	PlateAppearance
-	Defense            [9]PlayerID `yaml:",flow,omitempty"`
-	Runners            [3]PlayerID `yaml:",omitempty,flow"`
-	Comment            string      `yaml:",omitempty"`
-	LastState          *State      `yaml:"-"`
-	AlternativeFor     *State      `yaml:"-"`
+	Defense            [9]PlayerID    `yaml:",flow,omitempty"`
+	Runners            [3]PlayerID    `yaml:",omitempty,flow"`
+	Comment            string         `yaml:",omitempty"`
+	ScorerErrors       []*ScorerError `yaml:",omitempty"`
+	LastState          *State         `yaml:"-"`
+	AlternativeFor     *State         `yaml:"-"`
 	AlternativeCredits []*Player
+	// RunnerSubs are the runners who entered for a runner on base after
+	// the play, mapped to the runner they replaced
+	RunnerSubs map[PlayerID]PlayerID `yaml:",omitempty"`
 }
 
 type PlateAppearance struct {
@@ -45,6 +49,7 @@ type PlateAppearance struct {
 	Play
 	Batter PlayerID
 	Pitches
+	PitchIndex int  // index in Pitches of the pitch the play happened on, -1 if no pitches
 	Complete   bool `yaml:",omitempty"` // PA completed
 	Incomplete bool `yaml:",omitempty"` // inning ended w/batter still up
 	Modifiers  `yaml:",omitempty,flow"`
@@ -63,6 +68,35 @@ func (state *State) GetRunsScored() int {
 	return len(state.ScoringRunners)
 }
 
+// EventCount returns the count when the play happened, for a running
+// play this is the count before the pitch the play happened on.
+func (state *State) EventCount() (bool, string, int, int) {
+	return state.Pitches.EventPitches(state.PitchIndex).Count()
+}
+
+func (state *State) HasFieldingError(fielder int) bool {
+	if state.Play.FieldingError.Fielder == fielder {
+		return true
+	}
+	for _, adv := range state.Advances {
+		if adv.FieldingError.Fielder == fielder {
+			return true
+		}
+	}
+	return false
+}
+
+// GetErrorPlayer returns the player charged with a fielding error on
+// this play.
+func (state *State) GetErrorPlayer(fe FieldingError) PlayerID {
+	for _, se := range state.ScorerErrors {
+		if se.Fielder == fe.Fielder && se.Player != "" {
+			return se.Player
+		}
+	}
+	return state.Defense[fe.Fielder-1]
+}
+
 func (state *State) GetBaseRunner(base string) (runner PlayerID, err error) {
 	if base == "H" {
 		err = NewError("a runner cannot be at H", state.Pos)
@@ -81,7 +115,7 @@ func (state *State) GetBaseRunner(base string) (runner PlayerID, err error) {
 
 func (state *State) IsAB() bool {
 	return state.Complete &&
-		!(state.Play.Is(Walk, WalkPickedOff, HitByPitch, WalkWildPitch, WalkPassedBall, CatcherInterference) ||
+		!(state.Play.Is(Walk, WalkPickedOff, IntentionalWalk, HitByPitch, WalkWildPitch, WalkPassedBall, CatcherInterference) ||
 			(state.Play.Type == ReachedOnError && state.Modifiers.Contains(Obstruction)) ||
 			state.Modifiers.Contains(SacrificeFly, SacrificeHit))
 }
@@ -45,7 +45,10 @@ type Event struct {
 	RAdjRunner      Numbers           `parser:"| 'radj' @Token"`
 	RAdjBase        string            `parser:"      @Token (NL|EOF)"`
 	Score           string            `parser:"| 'score' @Token (NL|EOF)"`
-	Final           string            `parser:"| 'final' @Token (NL|EOF)"`
+	Final           string            `parser:"| 'final' @Token"`
+	FinalReason     string            `parser:"    @Token? (NL|EOF)"`
+	Err             string            `parser:"| 'err' @Token"`
+	ErrPlayer       string            `parser:"    @Token? (NL|EOF)"`
 	Play            *ActualPlay       `parser:"| @@"`
 	Comment         string            `parser:"   @Comment? (NL|EOF)"`
 	Empty           bool              `parser:"| @NL"`
@@ -288,7 +291,17 @@ func (f *File) writeEvents(w io.Writer, name string, events []*Event) {
 		case event.Score != "":
 			fmt.Fprintf(w, "score %s\n", event.Score)
 		case event.Final != "":
-			fmt.Fprintf(w, "final %s\n", event.Final)
+			fmt.Fprintf(w, "final %s", event.Final)
+			if event.FinalReason != "" {
+				fmt.Fprintf(w, " %s", event.FinalReason)
+			}
+			fmt.Fprintln(w)
+		case event.Err != "":
+			fmt.Fprintf(w, "err %s", event.Err)
+			if event.ErrPlayer != "" {
+				fmt.Fprintf(w, " %s", event.ErrPlayer)
+			}
+			fmt.Fprintln(w)
 		case event.Sub != nil:
 			fmt.Fprintf(w, "sub %s for %s\n", event.Sub.Enter, event.Sub.Exit)
 		case event.DefenseSub != nil:
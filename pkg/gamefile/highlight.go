@@ -0,0 +1,108 @@
+package gamefile
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+type HighlightKind int
+
+const (
+	HighlightKey HighlightKind = iota
+	HighlightValue
+	HighlightPlateAppearance
+	HighlightBatter
+	HighlightPitches
+	HighlightPlayCode
+	HighlightAdvance
+	HighlightKeyword
+	HighlightComment
+)
+
+// Highlight is a token of a game file for syntax highlighting.  Pos.Line
+// and Pos.Column start at 1 and Length is in bytes.
+type Highlight struct {
+	Kind   HighlightKind
+	Pos    Position
+	Length int
+}
+
+// GetHighlights returns the highlights of the tokens in text using the
+// game file lexer.  If plays is true, text is the events that follow the
+// --- line of a game file.  Highlighting stops at the first token the
+// lexer cannot read.
+func GetHighlights(text string, plays bool) []Highlight {
+	lineOffset := 0
+	if plays {
+		text = "---\n" + text
+		lineOffset = 1
+	}
+	lex, err := gameFileDef.LexString("", text)
+	if err != nil {
+		return nil
+	}
+	symbols := gameFileDef.Symbols()
+	var (
+		highlights []Highlight
+		// the kinds of the tokens that follow the PA number
+		fields []HighlightKind
+	)
+	add := func(kind HighlightKind, tok lexer.Token) {
+		pos := tok.Pos
+		pos.Line -= lineOffset
+		highlights = append(highlights, Highlight{Kind: kind, Pos: pos, Length: len(tok.Value)})
+	}
+	for {
+		tok, err := lex.Next()
+		if err != nil || tok.EOF() {
+			break
+		}
+		switch tok.Type {
+		case symbols["Key"]:
+			add(HighlightKey, tok)
+		case symbols["Value"]:
+			add(HighlightValue, tok)
+		case symbols["PA"]:
+			add(HighlightPlateAppearance, tok)
+			switch tok.Value {
+			case "alt":
+				fields = []HighlightKind{HighlightPlayCode}
+			case "...":
+				fields = []HighlightKind{HighlightPitches, HighlightPlayCode}
+			default:
+				fields = []HighlightKind{HighlightBatter, HighlightPitches, HighlightPlayCode}
+			}
+		case symbols["Keyword"]:
+			add(HighlightKeyword, tok)
+			fields = nil
+		case symbols["Token"]:
+			if len(fields) > 0 {
+				add(fields[0], tok)
+				fields = fields[1:]
+			} else {
+				add(HighlightValue, tok)
+			}
+		case symbols["Advance"]:
+			add(HighlightAdvance, tok)
+		case symbols["Comment"]:
+			add(HighlightComment, tok)
+		}
+	}
+	if !plays {
+		// the lexer drops the // comment lines of the properties
+		for i, line := range strings.Split(text, "\n") {
+			if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "//") {
+				highlights = append(highlights, Highlight{
+					Kind:   HighlightComment,
+					Pos:    Position{Line: i + 1, Column: len(line) - len(trimmed) + 1},
+					Length: len(trimmed),
+				})
+			}
+			if line == "---" {
+				break
+			}
+		}
+	}
+	return highlights
+}
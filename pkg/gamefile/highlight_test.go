@@ -0,0 +1,50 @@
+package gamefile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHighlights(t *testing.T) {
+	assert := assert.New(t)
+	highlights := GetHighlights(`pitching 24
+1 00 BCX S6/G6/B B-1 1-3(E3/TH) : bunt single
+... X 63/G6 -- ends the inning
+  alt 6/G6
+`, true)
+	type kinds struct {
+		kind         HighlightKind
+		line, column int
+		length       int
+	}
+	var actual []kinds
+	for _, h := range highlights {
+		actual = append(actual, kinds{h.Kind, h.Pos.Line, h.Pos.Column, h.Length})
+	}
+	assert.Equal([]kinds{
+		{HighlightKeyword, 1, 1, 8},
+		{HighlightValue, 1, 10, 2},
+		{HighlightPlateAppearance, 2, 1, 1},
+		{HighlightBatter, 2, 3, 2},
+		{HighlightPitches, 2, 6, 3},
+		{HighlightPlayCode, 2, 10, 7},
+		{HighlightAdvance, 2, 18, 3},
+		{HighlightAdvance, 2, 22, 10},
+		{HighlightComment, 2, 35, 11},
+		{HighlightPlateAppearance, 3, 1, 3},
+		{HighlightPitches, 3, 5, 1},
+		{HighlightPlayCode, 3, 7, 5},
+		{HighlightComment, 3, 16, 15},
+		{HighlightPlateAppearance, 4, 3, 3},
+		{HighlightPlayCode, 4, 7, 4},
+	}, actual)
+	highlights = GetHighlights("date: 5/30/22\n// played in the rain\n", false)
+	if assert.Len(highlights, 3) {
+		assert.Equal(HighlightKey, highlights[0].Kind)
+		assert.Equal(HighlightValue, highlights[1].Kind)
+		assert.Equal(7, highlights[1].Pos.Column)
+		assert.Equal(HighlightComment, highlights[2].Kind)
+		assert.Equal(2, highlights[2].Pos.Line)
+	}
+}
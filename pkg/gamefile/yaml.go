@@ -99,8 +99,9 @@ func (p *YAMLParser) parseYAMLEvents(pos lexer.Position, value interface{}) (eve
 			}
 		case "final":
 			events = append(events, &Event{
-				Pos:   pos,
-				Final: p.getPart(parts, 1),
+				Pos:         pos,
+				Final:       p.getPart(parts, 1),
+				FinalReason: p.getPart(parts, 2),
 			})
 		case "radj":
 			events = append(events, &Event{
@@ -109,7 +110,14 @@ func (p *YAMLParser) parseYAMLEvents(pos lexer.Position, value interface{}) (eve
 				RAdjBase:   p.getPart(parts, 2),
 			})
 		case "err":
-			// ignore
+			event := &Event{
+				Pos: pos,
+				Err: p.getPart(parts, 1),
+			}
+			if player := p.getPart(parts, 2); player != "" {
+				event.ErrPlayer = p.parseBatter(player)
+			}
+			events = append(events, event)
 		default:
 			play := &ActualPlay{
 				Pos:             pos,
@@ -146,6 +154,9 @@ func (p *YAMLParser) isPAComplete(code string) bool {
 		strings.HasPrefix(code, "PB") ||
 		strings.HasPrefix(code, "CS") ||
 		strings.HasPrefix(code, "PO") ||
+		strings.HasPrefix(code, "IP") ||
+		strings.HasPrefix(code, "AP") ||
+		strings.HasPrefix(code, "RINT") ||
 		code == "NP" ||
 		strings.HasPrefix(code, "FLE") {
 		return false
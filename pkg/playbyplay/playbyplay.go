@@ -269,8 +269,12 @@ func batterPlayDescription(state *game.State) string {
 		return "walks on passed ball"
 	case game.HomeRun:
 		return hitTrajectory(state, "hits a home run", nil)
+	case game.IntentionalWalk:
+		return "is intentionally walked"
 	case game.HitByPitch:
 		return "is hit by pitch"
+	case game.BatterInterference:
+		return "is out for batter's interference"
 	case game.CatcherInterference:
 		return "reaches on catcher's interference"
 	case game.ReachedOnError:
@@ -293,6 +297,8 @@ func batterPlayDescription(state *game.State) string {
 		return hitTrajectory(state, verb, play.Fielders)
 	case game.FlyOut:
 		return hitTrajectory(state, "is out", play.Fielders)
+	case game.InfieldFly:
+		return hitTrajectory(state, "is out on an infield fly", play.Fielders)
 	case game.DoublePlay:
 		verb := "grounds"
 		if state.Modifiers.Trajectory() == game.LineDrive {
@@ -335,6 +341,12 @@ func runningPlayDescription(team *game.Team, state, lastState *game.State) strin
 		return "On a wild pitch"
 	case state.Play.Type == game.PassedBall:
 		return "On a passed ball"
+	case state.Play.Type == game.IllegalPitch:
+		return "On an illegal pitch"
+	case state.Play.Type == game.AppealOut:
+		return fmt.Sprintf("%s is out on appeal", team.GetPlayer(play.PutOutRunner).NameOrNumber())
+	case state.Play.Type == game.RunnerInterference:
+		return fmt.Sprintf("%s is out for interference", team.GetPlayer(play.PutOutRunner).NameOrNumber())
 	default:
 		return ""
 	}
@@ -0,0 +1,144 @@
+package scorebook
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Canvas is a surface to draw a scorebook on.  Coordinates are in points
+// with y going down the page.
+type Canvas interface {
+	Line(x1, y1, x2, y2, width float64)
+	Rect(x, y, w, h float64)
+	Circle(x, y, r float64)
+	FillPolygon(xs, ys []float64)
+	// Text draws s with its baseline at y, starting at x if center is
+	// false or centered on x if center is true, in a monospaced font
+	Text(x, y, size float64, center bool, s string)
+}
+
+// Drawing is a page that can be drawn on a Canvas.
+type Drawing interface {
+	GetSize() (float64, float64)
+	Draw(Canvas)
+}
+
+const (
+	cellWidth   = 64.0
+	cellHeight  = 64.0
+	nameWidth   = 110.0
+	headerSize  = 18.0
+	runsHeight  = 18.0
+	titleHeight = 24.0
+	margin      = 18.0
+	halfGap     = 18.0
+)
+
+// getHalfSize returns the size of the drawing of a half.
+func (h *Half) getHalfSize() (float64, float64) {
+	return nameWidth + cellWidth*float64(len(h.Columns)) + cellWidth,
+		headerSize + cellHeight*float64(len(h.Lineup)) + runsHeight
+}
+
+// GetSize returns the size of the drawing of the scorebook.
+func (sb *Scorebook) GetSize() (float64, float64) {
+	vw, vh := sb.Visitor.getHalfSize()
+	hw, hh := sb.Home.getHalfSize()
+	return 2*margin + max(vw, hw), 2*margin + titleHeight + vh + halfGap + hh
+}
+
+// Draw draws the visitor's grid above the home team's grid.
+func (sb *Scorebook) Draw(c Canvas) {
+	title := fmt.Sprintf("%s at %s", sb.Game.Visitor.Name, sb.Game.Home.Name)
+	if date := sb.Game.GetDate(); date.Unix() != 0 {
+		title += date.Format("  Mon Jan 2, 2006")
+	}
+	if sb.Game.Number != "" {
+		title += fmt.Sprintf("  Game %s", sb.Game.Number)
+	}
+	c.Text(margin, margin+14, 14, false, title)
+	y := margin + titleHeight
+	sb.Visitor.draw(c, margin, y)
+	_, vh := sb.Visitor.getHalfSize()
+	sb.Home.draw(c, margin, y+vh+halfGap)
+}
+
+func (h *Half) draw(c Canvas, x, y float64) {
+	w, _ := h.getHalfSize()
+	rowsY := y + headerSize
+	// header
+	c.Rect(x, y, w, headerSize)
+	c.Text(x+4, y+13, 11, false, h.Team.Name)
+	for i, col := range h.Columns {
+		c.Text(x+nameWidth+cellWidth*(float64(i)+0.5), y+13, 11, true, fmt.Sprint(col.Inning))
+	}
+	c.Text(x+nameWidth+cellWidth*(float64(len(h.Columns))+0.5), y+13, 11, true, "R")
+	// lineup and cells
+	for slot, players := range h.Lineup {
+		cy := rowsY + cellHeight*float64(slot)
+		c.Rect(x, cy, nameWidth, cellHeight)
+		for i, player := range players {
+			p := h.Team.GetPlayer(player)
+			name := p.NameOrNumber()
+			if p.Number != "" && name != p.Number {
+				name = fmt.Sprintf("%s %s", p.Number, name)
+			}
+			c.Text(x+4, cy+14+12*float64(i), 10, false, truncate(name, 17))
+		}
+		for i, col := range h.Columns {
+			cx := x + nameWidth + cellWidth*float64(i)
+			c.Rect(cx, cy, cellWidth, cellHeight)
+			if cell := col.Cells[slot]; cell != nil {
+				drawCell(c, cx, cy, cell)
+			}
+		}
+		c.Rect(x+nameWidth+cellWidth*float64(len(h.Columns)), cy, cellWidth, cellHeight)
+	}
+	// runs by inning
+	ry := rowsY + cellHeight*float64(len(h.Lineup))
+	c.Rect(x, ry, w, runsHeight)
+	c.Text(x+4, ry+13, 11, false, "Runs")
+	shown := map[int]bool{}
+	for i, col := range h.Columns {
+		if !shown[col.Inning] {
+			shown[col.Inning] = true
+			c.Text(x+nameWidth+cellWidth*(float64(i)+0.5), ry+13, 11, true, fmt.Sprint(h.Runs[col.Inning]))
+		}
+	}
+	c.Text(x+nameWidth+cellWidth*(float64(len(h.Columns))+0.5), ry+13, 11, true, fmt.Sprint(h.GetRuns()))
+}
+
+// drawCell draws the pitches at the top of the cell, the diamond in the
+// middle with the path of the runner, and the play codes at the bottom.
+func drawCell(c Canvas, x, y float64, cell *Cell) {
+	c.Text(x+3, y+9, 7, false, truncate(string(cell.Pitches), 14))
+	// home, first, second and third
+	mx, my, r := x+cellWidth/2, y+cellHeight/2-1, 13.0
+	xs := []float64{mx, mx + r, mx, mx - r}
+	ys := []float64{my + r, my, my - r, my}
+	if cell.Bases == 4 {
+		c.FillPolygon(xs, ys)
+	}
+	for i := 0; i < 4; i++ {
+		width := 0.3
+		if i < cell.Bases {
+			width = 2
+		}
+		c.Line(xs[i], ys[i], xs[(i+1)%4], ys[(i+1)%4], width)
+	}
+	if cell.Out > 0 {
+		c.Circle(x+cellWidth-9, y+cellHeight-22, 5)
+		c.Text(x+cellWidth-9, y+cellHeight-19.5, 7, true, fmt.Sprint(cell.Out))
+	}
+	if cell.LeftOnBase {
+		c.Text(x+cellWidth-9, y+9, 7, true, "LOB")
+	}
+	c.Text(x+cellWidth/2, y+cellHeight-5, 8, true, truncate(strings.Join(cell.Codes, " "), 15))
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n-1] + "~"
+	}
+	return s
+}
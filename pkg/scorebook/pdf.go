@@ -0,0 +1,103 @@
+package scorebook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PDF is a Canvas that draws a page of a PDF document.  PDF y
+// coordinates go up the page, so they are flipped using the page height.
+type PDF struct {
+	bytes.Buffer
+	Height float64
+}
+
+// circleK is the distance of the Bezier control points that approximate a
+// quarter circle
+const circleK = 0.5523
+
+func (p *PDF) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(p, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, p.Height-y1, x2, p.Height-y2)
+}
+
+func (p *PDF) Rect(x, y, w, h float64) {
+	fmt.Fprintf(p, "0.5 w %.2f %.2f %.2f %.2f re S\n", x, p.Height-y-h, w, h)
+}
+
+func (p *PDF) Circle(x, y, r float64) {
+	y = p.Height - y
+	k := r * circleK
+	fmt.Fprintf(p, "0.5 w %.2f %.2f m\n", x+r, y)
+	fmt.Fprintf(p, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x+r, y+k, x+k, y+r, x, y+r)
+	fmt.Fprintf(p, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x-k, y+r, x-r, y+k, x-r, y)
+	fmt.Fprintf(p, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x-r, y-k, x-k, y-r, x, y-r)
+	fmt.Fprintf(p, "%.2f %.2f %.2f %.2f %.2f %.2f c S\n", x+k, y-r, x+r, y-k, x+r, y)
+}
+
+func (p *PDF) FillPolygon(xs, ys []float64) {
+	for i := range xs {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(p, "%.2f %.2f %s ", xs[i], p.Height-ys[i], op)
+	}
+	p.WriteString("h 0.6 g f 0 g\n")
+}
+
+func (p *PDF) Text(x, y, size float64, center bool, text string) {
+	if center {
+		// every Courier glyph is 0.6 of the font size wide
+		x -= float64(len(text)) * size * 0.6 / 2
+	}
+	fmt.Fprintf(p, "BT /F1 %.1f Tf %.2f %.2f Td (%s) Tj ET\n", size, x, p.Height-y, escapePDF(text))
+}
+
+func escapePDF(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// WritePDF writes the drawings as a PDF document with a page for each.
+func WritePDF(w io.Writer, pages []Drawing) error {
+	var (
+		out     bytes.Buffer
+		offsets []int
+		kids    []string
+	)
+	addObject := func(obj string) int {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), obj)
+		return len(offsets)
+	}
+	out.WriteString("%PDF-1.4\n")
+	// the catalog, page tree and font are objects 1-3, and each page is a
+	// page object followed by its contents
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	offsets = append(offsets, 0)
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>")
+	for _, d := range pages {
+		width, height := d.GetSize()
+		p := &PDF{Height: height}
+		d.Draw(p)
+		page := addObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			width, height, len(offsets)+2))
+		addObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.Len(), p.String()))
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+	// the page tree is written last since it lists the pages
+	pagesOffset := out.Len()
+	fmt.Fprintf(&out, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n",
+		strings.Join(kids, " "), len(kids))
+	offsets[1] = pagesOffset
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
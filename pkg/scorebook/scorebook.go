@@ -0,0 +1,223 @@
+package scorebook
+
+import (
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+// Cell is a plate appearance in a scorebook grid.
+type Cell struct {
+	Batter  game.PlayerID
+	Pitches game.Pitches
+	// Codes are the play codes of the plate appearance, i.e. a stolen
+	// base during it and then the play that ended it
+	Codes []string
+	// Bases is how far the batter got as a runner, 4 if the runner scored
+	Bases int
+	// Out is the number of the out (1-3) if the batter or the runner was
+	// put out, or 0
+	Out int
+	// LeftOnBase is true if the batter was on base when the inning ended
+	LeftOnBase bool
+}
+
+// Column is an inning in a scorebook grid.  An inning where the lineup
+// bats around has more than one column.
+type Column struct {
+	Inning int
+	Cells  []*Cell
+}
+
+// Half is the scorebook grid of one team's plate appearances, with a
+// row for each lineup slot and a column for each inning.
+type Half struct {
+	Team *game.Team
+	// Lineup is the batters in each lineup slot, the starter first
+	Lineup  [][]game.PlayerID
+	Columns []*Column
+	// Runs are the runs scored in each inning
+	Runs map[int]int
+}
+
+// Scorebook is the scorebook grid of a game.
+type Scorebook struct {
+	Game    *game.Game
+	Visitor *Half
+	Home    *Half
+}
+
+func New(g *game.Game) *Scorebook {
+	return &Scorebook{
+		Game:    g,
+		Visitor: newHalf(g.Visitor, g.GetVisitorStates()),
+		Home:    newHalf(g.Home, g.GetHomeStates()),
+	}
+}
+
+// startsPlateAppearance returns true if state is the first of a plate
+// appearance, and if the plate appearance is for the next lineup slot
+// rather than a batter who was up when the last inning ended.
+func startsPlateAppearance(last, state *game.State) (bool, bool) {
+	switch {
+	case last == nil || last.Complete:
+		return true, true
+	case last.Incomplete:
+		return true, false
+	}
+	return false, false
+}
+
+// getLineupSize returns the number of lineup slots, which is the number
+// of plate appearances before a batter comes up again.
+func getLineupSize(states []*game.State) int {
+	var (
+		seen = map[game.PlayerID]bool{}
+		last *game.State
+	)
+	for _, state := range states {
+		if _, next := startsPlateAppearance(last, state); next {
+			if seen[state.Batter] {
+				return len(seen)
+			}
+			seen[state.Batter] = true
+		}
+		last = state
+	}
+	return len(seen)
+}
+
+func newHalf(team *game.Team, states []*game.State) *Half {
+	h := &Half{
+		Team: team,
+		Runs: map[int]int{},
+	}
+	size := getLineupSize(states)
+	if size == 0 {
+		return h
+	}
+	h.Lineup = make([][]game.PlayerID, size)
+	var (
+		last  *game.State
+		pa    = -1
+		cell  *Cell
+		cells = map[game.PlayerID]*Cell{}
+		// the columns of the current inning
+		inning []*Column
+	)
+	for _, state := range states {
+		if last != nil && last.InningNumber != state.InningNumber {
+			inning = nil
+			cells = map[game.PlayerID]*Cell{}
+		}
+		if start, next := startsPlateAppearance(last, state); start {
+			if next {
+				pa++
+			}
+			slot := pa % size
+			if !contains(h.Lineup[slot], state.Batter) {
+				h.Lineup[slot] = append(h.Lineup[slot], state.Batter)
+			}
+			cell = &Cell{Batter: state.Batter}
+			cells[state.Batter] = cell
+			h.getColumn(&inning, state.InningNumber, slot, size).Cells[slot] = cell
+		}
+		cell.Pitches = state.Pitches
+		cell.Codes = append(cell.Codes, state.PlayCode)
+		h.Runs[state.InningNumber] += len(state.ScoringRunners)
+		h.moveRunners(last, state, cells)
+		last = state
+	}
+	return h
+}
+
+// getColumn returns the column of the inning that the lineup slot bats
+// in, adding a column if the slot has batted in all of them.
+func (h *Half) getColumn(inning *[]*Column, number, slot, size int) *Column {
+	for _, col := range *inning {
+		if col.Cells[slot] == nil {
+			return col
+		}
+	}
+	col := &Column{Inning: number, Cells: make([]*Cell, size)}
+	h.Columns = append(h.Columns, col)
+	*inning = append(*inning, col)
+	return col
+}
+
+// moveRunners records how far each runner got and who was put out on
+// the play.
+func (h *Half) moveRunners(last, state *game.State, cells map[game.PlayerID]*Cell) {
+	var (
+		before []game.PlayerID
+		// the base each runner in before started from
+		from []string
+		outs int
+	)
+	if last != nil && last.InningNumber == state.InningNumber && last.Outs < 3 {
+		before = append(before, last.Runners[:]...)
+		from = append(from, "1", "2", "3")
+		outs = last.Outs
+	}
+	if state.Complete {
+		before = append(before, state.Batter)
+		from = append(from, "B")
+	}
+	// a courtesy runner or sub carries on the cell of the runner replaced
+	for enter, exit := range state.RunnerSubs {
+		if c := cells[exit]; c != nil {
+			cells[enter] = c
+		}
+	}
+	safe := map[*Cell]bool{}
+	for i, runner := range state.Runners {
+		if c := cells[runner]; c != nil && runner != "" {
+			safe[c] = true
+			if c.Bases < i+1 {
+				c.Bases = i + 1
+			}
+		}
+	}
+	for _, runner := range state.ScoringRunners {
+		if c := cells[runner]; c != nil {
+			safe[c] = true
+			c.Bases = 4
+		}
+	}
+	// the runners put out on an advance are numbered first
+	for _, putOut := range []bool{true, false} {
+		for i, runner := range before {
+			c := cells[runner]
+			if runner == "" || c == nil || safe[c] || c.Out > 0 || outs >= state.Outs {
+				continue
+			}
+			if adv := state.Advances.From(from[i]); (adv != nil && adv.Out) != putOut {
+				continue
+			}
+			outs++
+			c.Out = outs
+		}
+	}
+	if state.Outs == 3 {
+		for _, runner := range state.Runners {
+			if c := cells[runner]; c != nil && runner != "" && c.Out == 0 && c.Bases < 4 {
+				c.LeftOnBase = true
+			}
+		}
+	}
+}
+
+func contains(players []game.PlayerID, player game.PlayerID) bool {
+	for _, p := range players {
+		if p == player {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRuns returns the total runs scored.
+func (h *Half) GetRuns() (runs int) {
+	for _, r := range h.Runs {
+		runs += r
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package scorebook
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScorebook(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 9
+1 1 X S8/G8
+2 2 CSS K
+3 3 BX H/F8 B-H 1-H
+4 4 CSS K
+5 1 CSS K
+6 2 X S8/G8
+7 3 X 8/F8
+8 4 X 8/F8
+9 1 X 8/F8
+homeplays
+pitching 8
+1 1 CSS K
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	sb := New(g)
+	h := sb.Visitor
+	assert.Len(h.Lineup, 4)
+	assert.Equal([]game.PlayerID{"1"}, h.Lineup[0])
+	// the lineup bats around in the 1st
+	if !assert.Len(h.Columns, 3) {
+		return
+	}
+	assert.Equal(1, h.Columns[0].Inning)
+	assert.Equal(1, h.Columns[1].Inning)
+	assert.Equal(2, h.Columns[2].Inning)
+	assert.Equal(map[int]int{1: 2, 2: 0}, h.Runs)
+	assert.Equal(2, h.GetRuns())
+	first := h.Columns[0].Cells
+	assert.Equal(4, first[0].Bases)
+	assert.Equal(game.Pitches("X"), first[0].Pitches)
+	assert.Equal([]string{"S8/G8"}, first[0].Codes)
+	assert.Equal(1, first[1].Out)
+	assert.Equal(4, first[2].Bases)
+	assert.Zero(first[2].Out)
+	assert.Equal(2, first[3].Out)
+	assert.Equal(3, h.Columns[1].Cells[0].Out)
+	assert.Nil(h.Columns[1].Cells[1])
+	second := h.Columns[2].Cells
+	assert.Equal(1, second[1].Bases)
+	assert.True(second[1].LeftOnBase)
+	assert.Equal([]int{1, 2, 3}, []int{second[2].Out, second[3].Out, second[0].Out})
+	assert.Len(sb.Home.Lineup, 1)
+	var s bytes.Buffer
+	assert.NoError(sb.WriteText(&s))
+	assert.Contains(s.String(), "S8/G8")
+	s.Reset()
+	assert.NoError(WriteSVG(&s, sb))
+	assert.True(strings.HasPrefix(s.String(), "<svg"))
+	s.Reset()
+	assert.NoError(WritePDF(&s, []Drawing{sb, sb}))
+	assert.Contains(s.String(), "/Count 2")
+	assert.True(strings.HasSuffix(s.String(), "%%EOF\n"))
+}
+
+func TestScorebookCourtesyRunner(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 9
+1 1 X S8/G8 cr 9
+2 2 X 8/F8
+3 3 X S8/G8 1-2
+4 4 X S8/G8 2-H 1-2
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	h := New(g).Visitor
+	if !assert.Len(h.Columns, 1) {
+		return
+	}
+	cells := h.Columns[0].Cells
+	// the batter's cell follows the courtesy runner home
+	assert.Zero(cells[0].Out)
+	assert.Equal(4, cells[0].Bases)
+	assert.Equal(1, cells[1].Out)
+	assert.Equal(2, cells[2].Bases)
+	assert.Equal(1, cells[3].Bases)
+}
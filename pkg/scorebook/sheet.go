@@ -0,0 +1,303 @@
+package scorebook
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+)
+
+// Scoresheet is a blank scoresheet for a game to be scored on paper,
+// filled in with the game's properties and the rosters of the teams.
+type Scoresheet struct {
+	Visitor    *game.Team
+	Home       *game.Team
+	Date       string
+	Number     string
+	Tournament string
+}
+
+const (
+	pageWidth   = 612.0
+	pageHeight  = 792.0
+	pageMargin  = 36.0
+	sheetRow    = 17.0
+	lineupRow   = 20.0
+	rosterRow   = 13.0
+	lineupSlots = 12
+)
+
+// blank is written for a property that's not known yet
+const blank = "__________"
+
+// playColumns are the columns of a line of plays in a game file.
+var playColumns = []struct {
+	name  string
+	width float64
+}{
+	{"PA", 28},
+	{"Batter", 44},
+	{"Pitches", 116},
+	{"Play", 136},
+	{"Advances", 136},
+	{"Notes", 80},
+}
+
+func NewScoresheet(gf *gamefile.File) (*Scoresheet, error) {
+	dir := filepath.Dir(gf.Path)
+	visitor, err := game.GetTeam(dir, gf.Properties["visitor"], gf.Properties["visitorid"])
+	if err != nil {
+		return nil, err
+	}
+	home, err := game.GetTeam(dir, gf.Properties["home"], gf.Properties["homeid"])
+	if err != nil {
+		return nil, err
+	}
+	return &Scoresheet{
+		Visitor:    visitor,
+		Home:       home,
+		Date:       gf.Properties["date"],
+		Number:     gf.Properties["game"],
+		Tournament: gf.Properties["tournament"],
+	}, nil
+}
+
+// GetPages returns a lineup card for each team with a roster, followed
+// by a sheet for the visitor's plays and a sheet for the home plays.
+func (s *Scoresheet) GetPages() []Drawing {
+	var pages []Drawing
+	for _, team := range []*game.Team{s.Visitor, s.Home} {
+		if len(getRoster(team)) > 0 {
+			pages = append(pages, &lineupCard{sheet: s, team: team})
+		}
+	}
+	return append(pages,
+		&playsSheet{sheet: s, section: "visitorplays", batting: s.Visitor, fielding: s.Home},
+		&playsSheet{sheet: s, section: "homeplays", batting: s.Home, fielding: s.Visitor})
+}
+
+func (s *Scoresheet) getTitle() string {
+	return fmt.Sprintf("%s at %s", getTeamName(s.Visitor), getTeamName(s.Home))
+}
+
+func (s *Scoresheet) getSubtitle() string {
+	sub := fmt.Sprintf("Date %s  Game %s", orBlank(s.Date), orBlank(s.Number))
+	if s.Tournament != "" {
+		sub += "  " + s.Tournament
+	}
+	return sub
+}
+
+func getTeamName(team *game.Team) string {
+	if team == nil {
+		return blank
+	}
+	return orBlank(team.Name)
+}
+
+func orBlank(s string) string {
+	if s == "" {
+		return blank
+	}
+	return s
+}
+
+// getRoster returns the active players of team in number order.
+func getRoster(team *game.Team) []*game.Player {
+	var roster []*game.Player
+	for _, p := range team.Players {
+		if !p.Inactive {
+			roster = append(roster, p)
+		}
+	}
+	sort.Slice(roster, func(i, j int) bool {
+		ni, erri := strconv.Atoi(roster[i].Number)
+		nj, errj := strconv.Atoi(roster[j].Number)
+		switch {
+		case erri == nil && errj == nil && ni != nj:
+			return ni < nj
+		case (erri == nil) != (errj == nil):
+			return erri == nil
+		case roster[i].Number != roster[j].Number:
+			return roster[i].Number < roster[j].Number
+		}
+		return roster[i].Name < roster[j].Name
+	})
+	return roster
+}
+
+func getRosterEntry(p *game.Player) string {
+	if p.Name == "" {
+		return p.Number
+	}
+	return fmt.Sprintf("%s %s", p.Number, p.Name)
+}
+
+// playsSheet is a page of lines for the plays of one team, laid out like
+// the plays in a game file.
+type playsSheet struct {
+	sheet    *Scoresheet
+	section  string
+	batting  *game.Team
+	fielding *game.Team
+}
+
+func (ps *playsSheet) GetSize() (float64, float64) {
+	return pageWidth, pageHeight
+}
+
+func (ps *playsSheet) Draw(c Canvas) {
+	x, y := pageMargin, pageMargin+14
+	width := pageWidth - 2*pageMargin
+	c.Text(x, y, 14, false, ps.sheet.getTitle())
+	y += 16
+	c.Text(x, y, 10, false, ps.sheet.getSubtitle())
+	y += 8
+	y = drawLineScore(c, ps.sheet, x, y) + 16
+	for _, r := range []struct {
+		label string
+		team  *game.Team
+	}{
+		{"Batting", ps.batting},
+		{"Pitching", ps.fielding},
+	} {
+		if roster := getRoster(r.team); len(roster) > 0 {
+			entries := make([]string, len(roster))
+			for i, p := range roster {
+				entries[i] = getRosterEntry(p)
+			}
+			for _, line := range wrapText(fmt.Sprintf("%s: %s", r.label, strings.Join(entries, ", ")), width, 8) {
+				c.Text(x, y, 8, false, line)
+				y += 10
+			}
+		}
+	}
+	y += 6
+	c.Text(x, y, 11, false, ps.section)
+	c.Text(x+width/2, y, 11, false, "pitching ______")
+	y += 6
+	// the header row and then a row for each plate appearance
+	rows := int((pageHeight - pageMargin - y) / sheetRow)
+	for row := 0; row < rows; row++ {
+		cx := x
+		for _, col := range playColumns {
+			c.Rect(cx, y, col.width, sheetRow)
+			switch {
+			case row == 0:
+				c.Text(cx+3, y+12, 9, false, col.name)
+			case col.name == "PA":
+				c.Text(cx+col.width/2, y+12, 8, true, fmt.Sprint(row))
+			}
+			cx += col.width
+		}
+		y += sheetRow
+	}
+}
+
+// drawLineScore draws a blank line score and returns the y it ends at.
+func drawLineScore(c Canvas, s *Scoresheet, x, y float64) float64 {
+	const (
+		teamWidth = 150.0
+		runWidth  = 24.0
+		rowHeight = 15.0
+	)
+	labels := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "R", "H", "E"}
+	for row, name := range []string{"", getTeamName(s.Visitor), getTeamName(s.Home)} {
+		c.Rect(x, y, teamWidth, rowHeight)
+		c.Text(x+3, y+11, 9, false, truncate(name, 27))
+		for i, label := range labels {
+			cx := x + teamWidth + runWidth*float64(i)
+			c.Rect(cx, y, runWidth, rowHeight)
+			if row == 0 {
+				c.Text(cx+runWidth/2, y+11, 9, true, label)
+			}
+		}
+		y += rowHeight
+	}
+	return y
+}
+
+// wrapText splits s into lines that fit in width at a font size.
+func wrapText(s string, width, size float64) []string {
+	n := int(width / (size * 0.6))
+	var lines []string
+	for len(s) > n {
+		i := strings.LastIndexByte(s[:n+1], ' ')
+		if i <= 0 {
+			i = n
+		}
+		lines = append(lines, s[:i])
+		s = strings.TrimLeft(s[i:], " ")
+	}
+	return append(lines, s)
+}
+
+// lineupCard is the lineup card of a team for the umpire, with blank
+// slots for the batting order and the team's roster.
+type lineupCard struct {
+	sheet *Scoresheet
+	team  *game.Team
+}
+
+func (lc *lineupCard) GetSize() (float64, float64) {
+	// half a letter page
+	return pageHeight / 2, pageWidth
+}
+
+func (lc *lineupCard) Draw(c Canvas) {
+	const margin = 24.0
+	width, _ := lc.GetSize()
+	width -= 2 * margin
+	x, y := margin, margin+14
+	c.Text(x+width/2, y, 14, true, "LINEUP")
+	y += 18
+	c.Text(x, y, 11, false, truncate(lc.team.Name, 48))
+	y += 14
+	opponent := lc.sheet.Home
+	if lc.team == lc.sheet.Home {
+		opponent = lc.sheet.Visitor
+	}
+	c.Text(x, y, 9, false, truncate("vs "+getTeamName(opponent), 58))
+	y += 12
+	c.Text(x, y, 9, false, truncate(lc.sheet.getSubtitle(), 58))
+	y += 8
+	columns := []struct {
+		name  string
+		width float64
+	}{
+		{"", 24},
+		{"No", 36},
+		{"Name", width - 24 - 36 - 40},
+		{"Pos", 40},
+	}
+	for row := 0; row <= lineupSlots; row++ {
+		cx := x
+		for i, col := range columns {
+			c.Rect(cx, y, col.width, lineupRow)
+			switch {
+			case row == 0:
+				c.Text(cx+3, y+14, 9, false, col.name)
+			case i == 0:
+				c.Text(cx+col.width/2, y+14, 9, true, fmt.Sprint(row))
+			}
+			cx += col.width
+		}
+		y += lineupRow
+	}
+	y += 18
+	c.Text(x, y, 10, false, "Roster")
+	y += 4
+	roster := getRoster(lc.team)
+	rows := (len(roster) + 1) / 2
+	for i, p := range roster {
+		cx, cy := x, y+rosterRow*float64(i)
+		if i >= rows {
+			cx, cy = x+width/2, y+rosterRow*float64(i-rows)
+		}
+		c.Text(cx, cy+rosterRow-3, 8, false, truncate(getRosterEntry(p), 28))
+	}
+}
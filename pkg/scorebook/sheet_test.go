@@ -0,0 +1,62 @@
+package scorebook
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoresheet(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-2.gm", `date: 5/21/22
+game: 2
+visitor: a
+tournament: Memorial Day
+---
+visitorplays
+pitching 0
+1 1 . NP
+homeplays
+pitching 0
+1 1 . NP
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	sheet, err := NewScoresheet(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("a at __________", sheet.getTitle())
+	assert.Equal("Date 5/21/22  Game 2  Memorial Day", sheet.getSubtitle())
+	// no rosters so no lineup cards
+	assert.Len(sheet.GetPages(), 2)
+	sheet.Visitor.Players = map[game.PlayerID]*game.Player{
+		"mf17": {Name: "Miya F", Number: "17"},
+		"aw6":  {Name: "Angelina W", Number: "6"},
+		"bj00": {Name: "Bronwyn J", Number: "00", Inactive: true},
+	}
+	roster := getRoster(sheet.Visitor)
+	if assert.Len(roster, 2) {
+		assert.Equal("6 Angelina W", getRosterEntry(roster[0]))
+		assert.Equal("17 Miya F", getRosterEntry(roster[1]))
+	}
+	pages := sheet.GetPages()
+	assert.Len(pages, 3)
+	var s bytes.Buffer
+	assert.NoError(WriteSVG(&s, pages[0]))
+	assert.Contains(s.String(), "Miya F")
+	s.Reset()
+	assert.NoError(WritePDF(&s, pages))
+	assert.Contains(s.String(), "/Count 3")
+}
+
+func TestWrapText(t *testing.T) {
+	assert := assert.New(t)
+	// 10 characters per line
+	assert.Equal([]string{"one two", "three four", "five"}, wrapText("one two three four five", 60, 10))
+	assert.Equal([]string{"abcdefghij", "kl"}, wrapText("abcdefghijkl", 60, 10))
+}
@@ -0,0 +1,57 @@
+package scorebook
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// SVG is a Canvas that draws an SVG document.
+type SVG struct {
+	strings.Builder
+}
+
+func (s *SVG) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(s, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black" stroke-width="%.1f"/>`+"\n",
+		x1, y1, x2, y2, width)
+}
+
+func (s *SVG) Rect(x, y, w, h float64) {
+	fmt.Fprintf(s, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="none" stroke="black" stroke-width="0.5"/>`+"\n",
+		x, y, w, h)
+}
+
+func (s *SVG) Circle(x, y, r float64) {
+	fmt.Fprintf(s, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="none" stroke="black" stroke-width="0.5"/>`+"\n",
+		x, y, r)
+}
+
+func (s *SVG) FillPolygon(xs, ys []float64) {
+	points := make([]string, len(xs))
+	for i := range xs {
+		points[i] = fmt.Sprintf("%.1f,%.1f", xs[i], ys[i])
+	}
+	fmt.Fprintf(s, `<polygon points="%s" fill="gray"/>`+"\n", strings.Join(points, " "))
+}
+
+func (s *SVG) Text(x, y, size float64, center bool, text string) {
+	anchor := "start"
+	if center {
+		anchor = "middle"
+	}
+	fmt.Fprintf(s, `<text x="%.1f" y="%.1f" font-family="monospace" font-size="%.1f" text-anchor="%s">%s</text>`+"\n",
+		x, y, size, anchor, html.EscapeString(text))
+}
+
+// WriteSVG writes d as an SVG document.
+func WriteSVG(w io.Writer, d Drawing) error {
+	s := &SVG{}
+	d.Draw(s)
+	width, height := d.GetSize()
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">
+<rect width="100%%" height="100%%" fill="white"/>
+%s</svg>
+`, width, height, width, height, s.String())
+	return err
+}
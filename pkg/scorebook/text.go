@@ -0,0 +1,104 @@
+package scorebook
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	textNameWidth = 16
+	textCellWidth = 11
+)
+
+// WriteText writes the scorebook as a grid of text, for a terminal.
+func (sb *Scorebook) WriteText(w io.Writer) error {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%s at %s\n\n", sb.Game.Visitor.Name, sb.Game.Home.Name)
+	sb.Visitor.writeText(&s)
+	s.WriteString("\n")
+	sb.Home.writeText(&s)
+	_, err := io.WriteString(w, s.String())
+	return err
+}
+
+func (h *Half) writeText(s *strings.Builder) {
+	rule := strings.Repeat("-", textNameWidth) +
+		strings.Repeat("+"+strings.Repeat("-", textCellWidth), len(h.Columns)) + "+\n"
+	fmt.Fprintf(s, "%-*.*s", textNameWidth, textNameWidth, h.Team.Name)
+	for _, col := range h.Columns {
+		fmt.Fprintf(s, "|%s", center(fmt.Sprint(col.Inning), textCellWidth))
+	}
+	s.WriteString("|\n")
+	s.WriteString(rule)
+	for slot, players := range h.Lineup {
+		lines := make([]string, 5)
+		for i := range lines {
+			var name string
+			if i < len(players) {
+				name = h.Team.GetPlayer(players[i]).NameOrNumber()
+			}
+			lines[i] = fmt.Sprintf("%-*.*s", textNameWidth, textNameWidth, name)
+		}
+		for _, col := range h.Columns {
+			for i, line := range getCellText(col.Cells[slot]) {
+				lines[i] += "|" + line
+			}
+		}
+		for _, line := range lines {
+			s.WriteString(line)
+			s.WriteString("|\n")
+		}
+		s.WriteString(rule)
+	}
+	fmt.Fprintf(s, "%-*s", textNameWidth, fmt.Sprintf("Runs %d", h.GetRuns()))
+	shown := map[int]bool{}
+	for _, col := range h.Columns {
+		var runs string
+		if !shown[col.Inning] {
+			shown[col.Inning] = true
+			runs = fmt.Sprint(h.Runs[col.Inning])
+		}
+		fmt.Fprintf(s, "|%s", center(runs, textCellWidth))
+	}
+	s.WriteString("|\n")
+}
+
+// getCellText returns the lines of a cell, the pitches, a diamond with
+// the bases reached marked, and the play codes with the out number.
+func getCellText(cell *Cell) []string {
+	blank := strings.Repeat(" ", textCellWidth)
+	if cell == nil {
+		return []string{blank, blank, blank, blank, blank}
+	}
+	base := func(n int) string {
+		if cell.Bases >= n {
+			return "*"
+		}
+		return "."
+	}
+	home := "."
+	if cell.Bases == 4 {
+		home = "R"
+	}
+	var result string
+	switch {
+	case cell.Out > 0:
+		result = fmt.Sprintf("(%d)", cell.Out)
+	case cell.LeftOnBase:
+		result = "LOB"
+	}
+	codes := truncate(strings.Join(cell.Codes, " "), textCellWidth-len(result)-1)
+	return []string{
+		fmt.Sprintf("%-*.*s", textCellWidth, textCellWidth, string(cell.Pitches)),
+		center(base(2), textCellWidth),
+		center(base(3)+"   "+base(1), textCellWidth),
+		center(home, textCellWidth),
+		fmt.Sprintf("%-*s%s", textCellWidth-len(result), codes, result),
+	}
+}
+
+func center(s string, width int) string {
+	left := (width - len(s)) / 2
+	return fmt.Sprintf("%*s%-*s", left, "", width-left, s)
+}
@@ -0,0 +1,397 @@
+package scout
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+)
+
+// Report is everything recorded against one opponent.
+type Report struct {
+	Team  string
+	Games []*game.Game
+
+	teamNames []string
+	gs        *stats.GameStats
+	hitters   map[playerKey]*hitter
+	pitchers  map[playerKey]*pitcher
+	runs      map[string]int
+}
+
+type playerKey struct {
+	team   string
+	player game.PlayerID
+}
+
+type hitter struct {
+	BallsInPlay                int
+	Ground, Fly, Line, Pop     int
+	Bunt                       int
+	Left, Center, Right, Spray int
+}
+
+type pitcher struct {
+	PA, FirstPitchStrikes int
+}
+
+func IsTeam(team *game.Team, id string) bool {
+	return strings.EqualFold(string(team.ID), id) ||
+		strings.HasPrefix(strings.ToLower(team.Name), strings.ToLower(id))
+}
+
+func NewReport(team string, games []*game.Game) (*Report, error) {
+	r := &Report{
+		Team:     team,
+		gs:       stats.NewGameStats(nil),
+		hitters:  map[playerKey]*hitter{},
+		pitchers: map[playerKey]*pitcher{},
+		runs:     map[string]int{},
+	}
+	names := map[string]bool{}
+	for _, g := range games {
+		var opponent *game.Team
+		switch {
+		case IsTeam(g.Home, team):
+			opponent = g.Home
+		case IsTeam(g.Visitor, team):
+			opponent = g.Visitor
+		default:
+			continue
+		}
+		r.Games = append(r.Games, g)
+		if !names[opponent.Name] {
+			names[opponent.Name] = true
+			r.teamNames = append(r.teamNames, opponent.Name)
+		}
+		if err := r.gs.Read(g); err != nil {
+			return nil, err
+		}
+		for _, state := range g.GetStates() {
+			batting := g.Visitor
+			if !state.Top() {
+				batting = g.Home
+			}
+			if batting == opponent {
+				r.recordBatting(opponent, state)
+			} else {
+				r.recordPitching(opponent, state)
+			}
+		}
+	}
+	sort.Strings(r.teamNames)
+	sort.SliceStable(r.Games, func(i, j int) bool {
+		return r.Games[i].GetDate().Before(r.Games[j].GetDate())
+	})
+	return r, nil
+}
+
+func (r *Report) recordBatting(team *game.Team, state *game.State) {
+	if runs := len(state.ScoringRunners); runs > 0 {
+		r.runs[getHowScored(state)] += runs
+	}
+	if !state.Complete || !state.Play.IsBallInPlay() {
+		return
+	}
+	key := playerKey{team.Name, state.Batter}
+	h := r.hitters[key]
+	if h == nil {
+		h = &hitter{}
+		r.hitters[key] = h
+	}
+	h.BallsInPlay++
+	switch state.Modifiers.Trajectory() {
+	case game.GroundBall:
+		h.Ground++
+	case game.FlyBall:
+		h.Fly++
+	case game.LineDrive:
+		h.Line++
+	case game.PopUp:
+		h.Pop++
+	case game.Bunt, game.BuntGrounder, game.BuntPopup:
+		h.Bunt++
+	}
+	fielder := 0
+	if loc := state.Modifiers.Location(); loc != nil {
+		fielder = loc.Fielder
+	} else if len(state.Play.Fielders) > 0 {
+		fielder = state.Play.Fielders[0]
+	}
+	switch fielder {
+	case 5, 6, 7:
+		h.Left++
+	case 1, 2, 8:
+		h.Center++
+	case 3, 4, 9:
+		h.Right++
+	default:
+		return
+	}
+	h.Spray++
+}
+
+func (r *Report) recordPitching(team *game.Team, state *game.State) {
+	if !state.Complete || state.Pitcher == "" {
+		return
+	}
+	first := rune(0)
+	for _, p := range state.Pitches {
+		if p != '.' && p != '?' {
+			first = p
+			break
+		}
+	}
+	if first == 0 {
+		return
+	}
+	key := playerKey{team.Name, state.Pitcher}
+	p := r.pitchers[key]
+	if p == nil {
+		p = &pitcher{}
+		r.pitchers[key] = p
+	}
+	p.PA++
+	if strings.ContainsRune("CSTMLFX", first) {
+		p.FirstPitchStrikes++
+	}
+}
+
+// getHowScored describes the play that scored runs.
+func getHowScored(state *game.State) string {
+	for _, adv := range state.Advances {
+		if adv.To == "H" && !adv.Out && adv.IsFieldingError() {
+			return "Error"
+		}
+	}
+	switch {
+	case state.Play.Is(game.Single):
+		return "Single"
+	case state.Play.Is(game.Double, game.GroundRuleDouble):
+		return "Double"
+	case state.Play.Is(game.Triple):
+		return "Triple"
+	case state.Play.Is(game.HomeRun):
+		return "Home run"
+	case state.Play.IsBaseOnBalls() || state.Play.Is(game.HitByPitch):
+		return "Walk or HBP"
+	case state.Play.Is(game.ReachedOnError, game.CatcherInterference):
+		return "Error"
+	case state.Play.Is(game.WildPitch, game.PassedBall, game.IllegalPitch):
+		return "WP, PB or IP"
+	case state.Play.Is(game.StolenBase, game.CaughtStealing, game.PickedOff):
+		return "Steal or pickoff"
+	case state.Modifiers.Contains(game.SacrificeFly):
+		return "Sac fly"
+	case state.Modifiers.Contains(game.SacrificeHit):
+		return "Sac bunt"
+	case state.Play.Is(game.GroundOut, game.FieldersChoice, game.DoublePlay):
+		return "Ground ball"
+	case state.Play.Is(game.FlyOut):
+		return "Fly ball"
+	}
+	return "Other"
+}
+
+func pct(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(d)
+}
+
+func (r *Report) GetHittersData() *dataframe.Data {
+	var (
+		name   = dataframe.NewColumn("Hitter", "%-14s", dataframe.EmptyStrings)
+		games  = dataframe.NewColumn("G", "%2d", dataframe.EmptyInts)
+		pa     = dataframe.NewColumn("PA", "%3d", dataframe.EmptyInts)
+		avg    = dataframe.NewColumn("AVG", "%5.3f", dataframe.EmptyFloats)
+		kPct   = dataframe.NewColumn("K%", "%4.0f", dataframe.EmptyFloats)
+		bbPct  = dataframe.NewColumn("BB%", "%4.0f", dataframe.EmptyFloats)
+		gbPct  = dataframe.NewColumn("GB%", "%4.0f", dataframe.EmptyFloats)
+		fbPct  = dataframe.NewColumn("FB%", "%4.0f", dataframe.EmptyFloats)
+		ldPct  = dataframe.NewColumn("LD%", "%4.0f", dataframe.EmptyFloats)
+		puPct  = dataframe.NewColumn("PU%", "%4.0f", dataframe.EmptyFloats)
+		left   = dataframe.NewColumn("Left%", "%5.0f", dataframe.EmptyFloats)
+		center = dataframe.NewColumn("Ctr%", "%4.0f", dataframe.EmptyFloats)
+		right  = dataframe.NewColumn("Right%", "%6.0f", dataframe.EmptyFloats)
+		bunts  = dataframe.NewColumn("Bunts", "%5d", dataframe.EmptyInts)
+		sba    = dataframe.NewColumn("SBA", "%3d", dataframe.EmptyInts)
+		sb     = dataframe.NewColumn("SB", "%3d", dataframe.EmptyInts)
+	)
+	for _, teamName := range r.teamNames {
+		ts := r.gs.TeamStats[teamName]
+		if ts == nil {
+			continue
+		}
+		for _, player := range ts.Batters {
+			b := ts.Batting[player]
+			if b.PA == 0 {
+				continue
+			}
+			b.Update()
+			h := r.hitters[playerKey{teamName, player}]
+			if h == nil {
+				h = &hitter{}
+			}
+			name.AppendString(b.Name)
+			games.AppendInt(b.Games)
+			pa.AppendInt(b.PA)
+			if b.AB > 0 {
+				avg.AppendFloat(float64(b.Hits) / float64(b.AB))
+			} else {
+				avg.AppendFloat(0)
+			}
+			kPct.AppendFloat(pct(b.StrikeOuts, b.PA))
+			bbPct.AppendFloat(pct(b.Walks, b.PA))
+			gbPct.AppendFloat(pct(h.Ground, h.BallsInPlay))
+			fbPct.AppendFloat(pct(h.Fly, h.BallsInPlay))
+			ldPct.AppendFloat(pct(h.Line, h.BallsInPlay))
+			puPct.AppendFloat(pct(h.Pop, h.BallsInPlay))
+			left.AppendFloat(pct(h.Left, h.Spray))
+			center.AppendFloat(pct(h.Center, h.Spray))
+			right.AppendFloat(pct(h.Right, h.Spray))
+			bunts.AppendInt(b.BuntHits + b.BuntSacrifices + b.BuntOuts + b.FoulBunts + b.MissedBunts)
+			sba.AppendInt(b.StolenBases + b.CaughtStealing)
+			sb.AppendInt(b.StolenBases)
+		}
+	}
+	return &dataframe.Data{
+		Name: "Hitters",
+		Columns: []*dataframe.Column{name, games, pa, avg, kPct, bbPct, gbPct, fbPct,
+			ldPct, puPct, left, center, right, bunts, sba, sb},
+	}
+}
+
+func (r *Report) GetPitchersData() *dataframe.Data {
+	var (
+		name    = dataframe.NewColumn("Pitcher", "%-14s", dataframe.EmptyStrings)
+		games   = dataframe.NewColumn("G", "%2d", dataframe.EmptyInts)
+		ip      = dataframe.NewColumn("IP", "%5s", dataframe.EmptyStrings)
+		bf      = dataframe.NewColumn("BF", "%3d", dataframe.EmptyInts)
+		pitches = dataframe.NewColumn("PC", "%4d", dataframe.EmptyInts)
+		strike  = dataframe.NewColumn("Strike%", "%7.0f", dataframe.EmptyFloats)
+		whiff   = dataframe.NewColumn("Whiff%", "%6.0f", dataframe.EmptyFloats)
+		fps     = dataframe.NewColumn("FPS%", "%4.0f", dataframe.EmptyFloats)
+		k       = dataframe.NewColumn("K", "%3d", dataframe.EmptyInts)
+		bb      = dataframe.NewColumn("BB", "%3d", dataframe.EmptyInts)
+	)
+	for _, teamName := range r.teamNames {
+		ts := r.gs.TeamStats[teamName]
+		if ts == nil {
+			continue
+		}
+		for _, player := range ts.Pitchers {
+			p := ts.Pitching[player]
+			if p.BattersFaced == 0 {
+				continue
+			}
+			p.Update()
+			fp := r.pitchers[playerKey{teamName, player}]
+			if fp == nil {
+				fp = &pitcher{}
+			}
+			name.AppendString(p.Name)
+			games.AppendInt(p.Games)
+			ip.AppendString(p.IP)
+			bf.AppendInt(p.BattersFaced)
+			pitches.AppendInt(p.Pitches)
+			strike.AppendFloat(pct(p.Strikes, p.Pitches))
+			whiff.AppendFloat(pct(p.Misses, p.Swings))
+			fps.AppendFloat(pct(fp.FirstPitchStrikes, fp.PA))
+			k.AppendInt(p.StrikeOuts)
+			bb.AppendInt(p.Walks)
+		}
+	}
+	return &dataframe.Data{
+		Name:    "Pitchers",
+		Columns: []*dataframe.Column{name, games, ip, bf, pitches, strike, whiff, fps, k, bb},
+	}
+}
+
+func (r *Report) GetRunsData() *dataframe.Data {
+	var (
+		how  = dataframe.NewColumn("How", "%-16s", dataframe.EmptyStrings)
+		runs = dataframe.NewColumn("Runs", "%4d", dataframe.EmptyInts)
+		pcts = dataframe.NewColumn("%", "%3.0f", dataframe.EmptyFloats)
+	)
+	total := 0
+	var hows []string
+	for h, n := range r.runs {
+		hows = append(hows, h)
+		total += n
+	}
+	sort.Slice(hows, func(i, j int) bool {
+		if r.runs[hows[i]] != r.runs[hows[j]] {
+			return r.runs[hows[i]] > r.runs[hows[j]]
+		}
+		return hows[i] < hows[j]
+	})
+	for _, h := range hows {
+		how.AppendString(h)
+		runs.AppendInt(r.runs[h])
+		pcts.AppendFloat(pct(r.runs[h], total))
+	}
+	runs.Summary = dataframe.Sum
+	return &dataframe.Data{
+		Name:    "How They Scored",
+		Columns: []*dataframe.Column{how, runs, pcts},
+	}
+}
+
+func (r *Report) getTitle() string {
+	if len(r.teamNames) == 0 {
+		return fmt.Sprintf("Scouting %s", r.Team)
+	}
+	return fmt.Sprintf("Scouting %s", strings.Join(r.teamNames, ", "))
+}
+
+func (r *Report) getGamesLine() string {
+	var dates []string
+	for _, g := range r.Games {
+		dates = append(dates, g.GetDate().Format("01/02/2006"))
+	}
+	return fmt.Sprintf("%d games: %s", len(r.Games), strings.Join(dates, ", "))
+}
+
+func (r *Report) RenderMarkdown(w io.Writer) error {
+	fmt.Fprintf(w, "# %s\n\n%s\n\n", r.getTitle(), r.getGamesLine())
+	for _, dat := range []*dataframe.Data{r.GetHittersData(), r.GetPitchersData(), r.GetRunsData()} {
+		if err := dat.RenderMarkdown(w); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func (r *Report) RenderHTML(w io.Writer) error {
+	fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%[1]s</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 2px 6px; text-align: right; }
+td:first-child, th:first-child { text-align: left; }
+</style>
+</head>
+<body>
+<h1>%[1]s</h1>
+<p>%[2]s</p>
+`, html.EscapeString(r.getTitle()), html.EscapeString(r.getGamesLine()))
+	for _, dat := range []*dataframe.Data{r.GetHittersData(), r.GetPitchersData(), r.GetRunsData()} {
+		if err := dat.RenderHTML(w); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(w, "</body>\n</html>")
+	return nil
+}
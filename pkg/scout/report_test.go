@@ -0,0 +1,35 @@
+package scout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	assert := assert.New(t)
+	g, err := game.ReadGameFile("../../data/pride-jf-16u/pgf-organizational-challenge/20220918-1.gm")
+	if !assert.NoError(err) {
+		return
+	}
+	r, err := NewReport("aasa", []*game.Game{g})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Len(r.Games, 1)
+	assert.Greater(r.GetHittersData().RowCount(), 0)
+	assert.Equal(1, r.GetPitchersData().RowCount())
+	runs := r.GetRunsData()
+	assert.Greater(runs.RowCount(), 0)
+	s := &strings.Builder{}
+	assert.NoError(r.RenderMarkdown(s))
+	assert.Contains(s.String(), "# How They Scored")
+	s.Reset()
+	assert.NoError(r.RenderHTML(s))
+	assert.Contains(s.String(), "<h2>Pitchers</h2>")
+	r, err = NewReport("nobody", []*game.Game{g})
+	assert.NoError(err)
+	assert.Empty(r.Games)
+}
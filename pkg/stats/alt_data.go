@@ -12,7 +12,8 @@ import (
 type AltData struct {
 	re RunExpectancy
 	game, half, inn, bat, o, rnr, play, alt,
-	cost, comment, credit *dataframe.Column
+	cost, replay, comment, credit *dataframe.Column
+	creditTeams [][]string
 }
 
 func NewAltData(re RunExpectancy) *AltData {
@@ -27,10 +28,12 @@ func NewAltData(re RunExpectancy) *AltData {
 		play:    dataframe.NewColumn("Reality", "%30s", dataframe.EmptyStrings),
 		alt:     dataframe.NewColumn("Alternate", "%30s", dataframe.EmptyStrings),
 		cost:    dataframe.NewColumn("RCost", "%6.2f", dataframe.EmptyFloats),
+		replay:  dataframe.NewColumn("Replay", "%6.2f", dataframe.EmptyFloats),
 		comment: dataframe.NewColumn("Comment", "%-20s", dataframe.EmptyStrings),
 		credit:  dataframe.NewColumn("Credit", "%s", dataframe.EmptyStrings),
 	}
 	alt.cost.Summary = dataframe.Sum
+	alt.replay.Summary = dataframe.Sum
 	return alt
 }
 
@@ -38,7 +41,7 @@ func (alt *AltData) GetData() *dataframe.Data {
 	dat := &dataframe.Data{
 		Columns: []*dataframe.Column{
 			alt.game, alt.inn, alt.half, alt.bat, alt.o, alt.rnr, alt.play,
-			alt.alt, alt.cost, alt.comment, alt.credit,
+			alt.alt, alt.cost, alt.replay, alt.comment, alt.credit,
 		},
 	}
 	return dat.RSort(dataframe.Less(dataframe.Descending(dataframe.CompareFloat(alt.cost))))
@@ -48,6 +51,7 @@ func (alt *AltData) GetPerPlayerData() *dataframe.Data {
 	dat := &dataframe.Data{
 		Columns: []*dataframe.Column{
 			dataframe.NewColumn("Player", "%-6s", dataframe.EmptyStrings),
+			dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings),
 			dataframe.NewColumn("Plays", "%-20s", dataframe.EmptyStrings),
 			dataframe.NewColumn("RCost", "%.2f", dataframe.EmptyFloats),
 		},
@@ -56,15 +60,16 @@ func (alt *AltData) GetPerPlayerData() *dataframe.Data {
 		if val != "" {
 			players := strings.Fields(val)
 			share := alt.cost.GetFloat(i) / float64(len(players))
-			for _, player := range players {
+			for j, player := range players {
 				dat.Columns[0].AppendString(player)
-				dat.Columns[1].AppendString(alt.play.GetString(i))
-				dat.Columns[2].AppendFloat(share)
+				dat.Columns[1].AppendString(alt.creditTeams[i][j])
+				dat.Columns[2].AppendString(alt.play.GetString(i))
+				dat.Columns[3].AppendFloat(share)
 			}
 		}
 	}
-	res := dat.GroupBy("Player").Aggregate(
-		dataframe.ASum("Cost", dat.Columns[2]).WithFormat("%4.2f").WithSummary(dataframe.Sum),
+	res := dat.GroupBy("Team", "Player").Aggregate(
+		dataframe.ASum("Cost", dat.Columns[3]).WithFormat("%4.2f").WithSummary(dataframe.Sum),
 		dataframe.AFunc("Plays", dataframe.String, func(acol *dataframe.Column, group *dataframe.Group) {
 			plays := &strings.Builder{}
 			for _, row := range group.Rows {
@@ -77,12 +82,12 @@ func (alt *AltData) GetPerPlayerData() *dataframe.Data {
 			acol.AppendString(plays.String())
 		}),
 	)
-	res = res.RSort(dataframe.Less(dataframe.Descending(dataframe.CompareFloat(res.Columns[1]))))
-	res.Arrange("Cost", "Player", "Plays")
+	res = res.RSort(dataframe.Less(dataframe.Descending(dataframe.CompareFloat(res.GetColumn("Cost")))))
+	res.Arrange("Cost", "Player", "Team", "Plays")
 	return res
 }
 
-func (alt *AltData) Record(gameID string, state *game.State) float64 {
+func (alt *AltData) Record(g *game.Game, state *game.State) float64 {
 	if alt.re == nil || state.AlternativeFor == nil {
 		return 0
 	}
@@ -98,7 +103,7 @@ func (alt *AltData) Record(gameID string, state *game.State) float64 {
 		outs = state.LastState.Outs
 	}
 	_, _, _, originalChange := GetExpectedRunsChange(alt.re, state.AlternativeFor)
-	alt.game.AppendString(gameID)
+	alt.game.AppendString(g.ID)
 	alt.bat.AppendString(string(state.Batter))
 	alt.o.AppendInt(outs)
 	alt.rnr.AppendString(string(GetOccupiedBases(state.LastState)))
@@ -109,18 +114,55 @@ func (alt *AltData) Record(gameID string, state *game.State) float64 {
 		price = -price
 	}
 	alt.cost.AppendFloat(price)
+	alt.replay.AppendFloat(alt.getReplayCost(g, state))
 	alt.comment.AppendString(state.Comment)
 	credit := &strings.Builder{}
+	var teams []string
 	for _, p := range getAltCredit(state) {
 		if credit.Len() > 0 {
 			credit.WriteRune(' ')
 		}
 		credit.WriteString(string(p))
+		teams = append(teams, getAltCreditTeam(state, p))
 	}
 	alt.credit.AppendString(credit.String())
+	alt.creditTeams = append(alt.creditTeams, teams)
 	return change
 }
 
+// getReplayCost returns the difference between the runs actually scored
+// in the rest of the half inning and the runs scored when the half inning
+// is replayed with the alternative play.  If the replay does not reach 3
+// outs, the expected runs of its last state are added.
+func (alt *AltData) getReplayCost(g *game.Game, state *game.State) float64 {
+	replay, err := g.ReplayAlternative(state)
+	if err != nil {
+		return 0
+	}
+	runs := float64(replay.Runs)
+	if !replay.Complete() {
+		final := replay.Final()
+		runs += alt.re.GetExpectedRuns(final.Outs, GetOccupiedBases(final))
+	}
+	price := float64(replay.ActualRuns) - runs
+	if state.BattingTeam.Us {
+		price = -price
+	}
+	return price
+}
+
+// getAltCreditTeam returns the name of the team of a player credited with
+// an alt play.
+func getAltCreditTeam(alt *game.State, player game.PlayerID) string {
+	for _, p := range alt.AlternativeCredits {
+		if p.PlayerID == player {
+			return p.Team.Name
+		}
+	}
+	// the other credits are for the fielders of the actual play
+	return alt.AlternativeFor.FieldingTeam.Name
+}
+
 func getAltCredit(alt *game.State) []game.PlayerID {
 	credits := map[game.PlayerID]bool{}
 	for _, p := range alt.AlternativeCredits {
@@ -128,7 +170,7 @@ func getAltCredit(alt *game.State) []game.PlayerID {
 	}
 	state := alt.AlternativeFor
 	if state.FieldingError.IsFieldingError() {
-		player := state.Defense[state.FieldingError.Fielder-1]
+		player := state.GetErrorPlayer(state.FieldingError)
 		if player != "" {
 			credits[player] = true
 		}
@@ -147,7 +189,7 @@ func getAltCredit(alt *game.State) []game.PlayerID {
 	}
 	for _, adv := range state.Advances {
 		if adv.IsFieldingError() {
-			fielder := state.Defense[adv.FieldingError.Fielder-1]
+			fielder := state.GetErrorPlayer(adv.FieldingError)
 			if fielder != "" {
 				credits[fielder] = true
 			}
@@ -0,0 +1,237 @@
+package stats
+
+import (
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+type Baserunning struct {
+	PlayerData                    `mapstructure:",squash"`
+	XBTOpp, XBT                   int
+	FirstToThirdOpp, FirstToThird int
+	SecondToHomeOpp, SecondToHome int
+	FirstToHomeOpp, FirstToHome   int
+	OutsOnBases                   int
+	WPAdvances, PBAdvances        int
+	THAdvances                    int
+	StolenBases, CaughtStealing   int
+	PickedOff                     int
+	XBTPct                        float64
+	BRRuns                        float64
+}
+
+var baseIndex = map[string]int{
+	"B": 0,
+	"1": 1,
+	"2": 2,
+	"3": 3,
+	"H": 4,
+}
+
+var indexBase = []string{"B", "1", "2", "3", "H"}
+
+func (br *Baserunning) Update() {
+	br.PlayerData.Update()
+	br.XBTPct = 0
+	if br.XBTOpp > 0 {
+		br.XBTPct = float64(br.XBT) / float64(br.XBTOpp)
+	}
+}
+
+func (stats *TeamStats) GetBaserunning(runner game.PlayerID) *Baserunning {
+	br := stats.Baserunning[runner]
+	if br == nil {
+		br = &Baserunning{
+			PlayerData: NewPlayerData(stats.Team.Name, stats.Team.GetPlayer(runner)),
+		}
+		stats.Baserunning[runner] = br
+		stats.Baserunners = append(stats.Baserunners, runner)
+	}
+	return br
+}
+
+func (stats *TeamStats) GetBaserunningData() *dataframe.Data {
+	dat := newData("BR")
+	var idx *dataframe.Index
+	for _, runner := range stats.Baserunners {
+		idx = dat.AppendStruct(idx, stats.Baserunning[runner])
+	}
+	return dat
+}
+
+// isForced returns true if the runner on base from has to advance when
+// the batter does.
+func isForced(last *game.State, from string) bool {
+	for i := 0; i < baseIndex[from]-1; i++ {
+		if last.Runners[i] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (stats *TeamStats) RecordBaserunning(g *game.Game, state *game.State, reChange float64) {
+	last := state.LastState
+	if last == nil {
+		last = &game.State{}
+	}
+	runningPlay := state.Play.Is(game.StolenBase, game.CaughtStealing, game.PickedOff,
+		game.StrikeOutStolenBase, game.StrikeOutCaughtStealing, game.StrikeOutPickedOff,
+		game.WalkPickedOff)
+	wildPitch := state.Play.Is(game.WildPitch, game.WalkWildPitch, game.StrikeOutWildPitch)
+	passedBall := state.Play.Is(game.PassedBall, game.WalkPassedBall, game.StrikeOutPassedBall)
+	groundBall := state.Play.Is(game.FieldersChoice, game.DoublePlay, game.GroundOut)
+	hitBases := 0
+	switch state.Play.Type {
+	case game.Single:
+		hitBases = 1
+	case game.Double:
+		hitBases = 2
+	case game.Triple:
+		hitBases = 3
+	}
+	var creditRunners []game.PlayerID
+	for _, from := range []string{"3", "2", "1", "B"} {
+		runner := state.Batter
+		if from != "B" {
+			runner = last.Runners[baseIndex[from]-1]
+		}
+		adv := state.Advances.From(from)
+		if runner == "" || (adv == nil && from == "B") {
+			continue
+		}
+		br := stats.GetBaserunning(runner)
+		br.GameAppearances[g.ID] = true
+		standard := from
+		if hitBases > 0 {
+			standard = indexBase[min(baseIndex[from]+hitBases, 4)]
+			recordHitOpportunity(br, state.Play.Type, from, adv)
+		}
+		if adv == nil {
+			continue
+		}
+		extra := !adv.Out && baseIndex[adv.To] > baseIndex[standard]
+		switch {
+		case adv.Out && !runningPlay:
+			// a runner forced out isn't a baserunning mistake
+			forcedOut := groundBall && isForced(last, from) && adv.To == indexBase[baseIndex[from]+1]
+			if (from != "B" || hitBases > 0) && !forcedOut {
+				br.OutsOnBases++
+			}
+		case adv.Steal:
+			br.StolenBases++
+		case wildPitch || adv.WildPitch:
+			if from != "B" {
+				br.WPAdvances++
+			}
+		case passedBall || adv.PassedBall:
+			if from != "B" {
+				br.PBAdvances++
+			}
+		}
+		if extra && state.Modifiers.Contains(game.Throwing) {
+			br.THAdvances++
+		}
+		if runningPlay || wildPitch || passedBall {
+			if from != "B" {
+				creditRunners = append(creditRunners, runner)
+			}
+		} else if hitBases > 0 && (adv.Out || extra) {
+			br.BRRuns += stats.getAdvanceValue(state, runner, adv, standard)
+		}
+	}
+	switch {
+	case state.Play.Is(game.CaughtStealing, game.StrikeOutCaughtStealing):
+		runner := stats.GetBaserunning(state.CaughtStealingRunner)
+		if !state.NotOutOnPlay {
+			runner.CaughtStealing++
+		}
+		creditRunners = []game.PlayerID{state.CaughtStealingRunner}
+	case state.Play.Is(game.PickedOff, game.WalkPickedOff, game.StrikeOutPickedOff):
+		runner := stats.GetBaserunning(state.PickedOffRunner)
+		if !state.NotOutOnPlay {
+			runner.PickedOff++
+		}
+		creditRunners = []game.PlayerID{state.PickedOffRunner}
+	}
+	if len(creditRunners) > 0 && stats.re != nil {
+		perRunner := reChange / float64(len(creditRunners))
+		for _, runner := range creditRunners {
+			stats.GetBaserunning(runner).BRRuns += perRunner
+		}
+	}
+}
+
+func recordHitOpportunity(br *Baserunning, hit game.PlayType, from string, adv *game.Advance) {
+	taken := func(to ...string) bool {
+		if adv == nil || adv.Out {
+			return false
+		}
+		for _, base := range to {
+			if adv.To == base {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case hit == game.Single && from == "1":
+		br.XBTOpp++
+		br.FirstToThirdOpp++
+		if taken("3", "H") {
+			br.XBT++
+			br.FirstToThird++
+		}
+	case hit == game.Single && from == "2":
+		br.XBTOpp++
+		br.SecondToHomeOpp++
+		if taken("H") {
+			br.XBT++
+			br.SecondToHome++
+		}
+	case hit == game.Double && from == "1":
+		br.XBTOpp++
+		br.FirstToHomeOpp++
+		if taken("H") {
+			br.XBT++
+			br.FirstToHome++
+		}
+	}
+}
+
+// getAdvanceValue returns the runs the runner on adv gained or lost
+// compared to stopping safely at the standard base for the hit.
+func (stats *TeamStats) getAdvanceValue(state *game.State, runner game.PlayerID, adv *game.Advance, standard string) float64 {
+	if stats.re == nil {
+		return 0
+	}
+	value := func(outs int, runners [3]game.PlayerID, runs int) float64 {
+		if outs >= 3 {
+			return float64(runs)
+		}
+		return stats.re.GetExpectedRuns(outs, GetOccupiedBases(&game.State{Runners: runners})) + float64(runs)
+	}
+	outs := state.Outs
+	runners := state.Runners
+	runs := len(state.ScoringRunners)
+	actual := value(outs, runners, runs)
+	switch {
+	case adv.Out:
+		outs--
+	case adv.To == "H":
+		runs--
+	default:
+		runners[baseIndex[adv.To]-1] = ""
+	}
+	if standard == "H" {
+		runs++
+	} else {
+		i := baseIndex[standard] - 1
+		if runners[i] != "" {
+			// another runner is already there
+			return 0
+		}
+		runners[i] = runner
+	}
+	return actual - value(outs, runners, runs)
+}
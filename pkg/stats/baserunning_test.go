@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaserunning(t *testing.T) {
+	assert := assert.New(t)
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X S8/G6 B-1
+2 2 X S8/G6 B-1 1-3
+3 3 X S7/L7 B-1 1-2 3-H
+4 4 X D8/F8 B-2 2-H 1X3(865)
+5 5 X 63/G6
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	gs := NewGameStats(re)
+	assert.NoError(gs.Read(g))
+	ts := gs.TeamStats["a"]
+	br := ts.Baserunning["1"]
+	if assert.NotNil(br) {
+		assert.Equal(1, br.FirstToThirdOpp)
+		assert.Equal(1, br.FirstToThird)
+		assert.Equal(1, br.XBT)
+		assert.Greater(br.BRRuns, 0.0)
+	}
+	br = ts.Baserunning["2"]
+	if assert.NotNil(br) {
+		assert.Equal(1, br.XBTOpp)
+		assert.Equal(0, br.XBT)
+	}
+	br = ts.Baserunning["3"]
+	if assert.NotNil(br) {
+		assert.Equal(1, br.FirstToHomeOpp)
+		assert.Equal(1, br.OutsOnBases)
+		assert.Less(br.BRRuns, 0.5)
+	}
+	teams := gs.GetTeamBaserunningData()
+	assert.Equal(1, teams.RowCount())
+}
+
+func TestBaserunningForceOuts(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X S8/G6 B-1
+2 2 X FC6/G6 B-1 1X2(64)
+3 3 X S8/G6 B-1 1-2
+4 4 X 6(1)43/GDP/G6 2-3
+5 5 X S8/G6 B-1
+6 6 X FC6/G6 B-1 1X3(65)
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	gs := NewGameStats(nil)
+	assert.NoError(gs.Read(g))
+	ts := gs.TeamStats["a"]
+	// forced out at second on a fielder's choice and a double play
+	assert.Equal(0, ts.Baserunning["1"].OutsOnBases)
+	assert.Equal(0, ts.Baserunning["3"].OutsOnBases)
+	// out trying for third on a fielder's choice
+	assert.Equal(1, ts.Baserunning["5"].OutsOnBases)
+}
@@ -12,6 +12,8 @@ type BattingCountSituations struct {
 	Us     string
 	NotUs  string
 	Direct bool
+	// RE, if set, is used for the run value of each count
+	RE RunExpectancy
 
 	sits []*CountSituation
 }
@@ -36,27 +38,41 @@ func NewBattingByCount() *BattingCountSituations {
 }
 
 func (bc *BattingCountSituations) Read(g *game.Game) {
+	for _, state := range getBattingStates(g, bc.Us, bc.NotUs) {
+		if bc.Direct {
+			bc.recordDirect(state)
+		} else {
+			bc.recordPassingThrough(state)
+		}
+	}
+}
+
+// getBattingStates returns the states of the team batting whose name
+// starts with us, or the team whose name does not start with notUs.
+func getBattingStates(g *game.Game, us, notUs string) []*game.State {
 	states := g.GetStates()
-	if bc.Us != "" {
-		if strings.HasPrefix(g.Home.Name, bc.Us) {
+	if us != "" {
+		if strings.HasPrefix(g.Home.Name, us) {
 			states = g.GetHomeStates()
 		} else {
 			states = g.GetVisitorStates()
 		}
 	}
-	if bc.NotUs != "" {
-		if !strings.HasPrefix(g.Home.Name, bc.NotUs) {
+	if notUs != "" {
+		if !strings.HasPrefix(g.Home.Name, notUs) {
 			states = g.GetHomeStates()
 		} else {
 			states = g.GetVisitorStates()
 		}
 	}
-	for _, state := range states {
-		if bc.Direct {
-			bc.recordDirect(state)
-		} else {
-			bc.recordPassingThrough(state)
-		}
+	return states
+}
+
+func (bc *BattingCountSituations) record(sit *CountSituation, state *game.State) {
+	sit.Record(state)
+	if bc.RE != nil {
+		_, _, _, change := GetExpectedRunsChange(bc.RE, state)
+		sit.RE24 += change
 	}
 }
 
@@ -65,7 +81,7 @@ func (bc *BattingCountSituations) recordDirect(state *game.State) {
 		known, _, balls, strikes := state.Pitches[0 : len(state.Pitches)-1].Count()
 		if known {
 			sit := bc.sits[strikes*4+balls]
-			sit.Record(state)
+			bc.record(sit, state)
 		}
 	}
 }
@@ -84,7 +100,7 @@ func (bc *BattingCountSituations) recordPassingThrough(state *game.State) {
 			}
 		}
 		for _, sit := range sits {
-			sit.Record(state)
+			bc.record(sit, state)
 		}
 	}
 }
@@ -95,7 +111,7 @@ func (bc *BattingCountSituations) GetData() *dataframe.Data {
 	for _, sit := range bc.sits {
 		idx = dat.AppendStruct(idx, sit.Batting)
 	}
-	return dat.Select(
+	sels := []dataframe.Selection{
 		dataframe.DeriveStrings("Count", func(idx *dataframe.Index, i int) string {
 			return bc.sits[i].Count
 		}).WithFormat("%5s"),
@@ -109,5 +125,24 @@ func (bc *BattingCountSituations) GetData() *dataframe.Data {
 		dataframe.DeriveFloats("PGO%", PGO).WithFormat("%6.3f"),
 		dataframe.Col("PA").WithFormat("%4d"),
 		dataframe.Col("AB").WithFormat("%4d"),
-	)
+	}
+	if bc.RE != nil {
+		sels = append(sels,
+			dataframe.DeriveFloats("RV", bc.getRunValue).WithFormat("% 6.3f"),
+			dataframe.DeriveFloats("RV00", func(idx *dataframe.Index, i int) float64 {
+				return bc.getRunValue(idx, i) - bc.getRunValue(idx, 0)
+			}).WithFormat("% 6.3f"),
+		)
+	}
+	return dat.Select(sels...)
+}
+
+// getRunValue returns the average RE change of the plate appearances
+// through a count.
+func (bc *BattingCountSituations) getRunValue(idx *dataframe.Index, i int) float64 {
+	pa := idx.GetInt(i, "PA")
+	if pa == 0 {
+		return 0
+	}
+	return idx.GetFloat(i, "RE24") / float64(pa)
 }
@@ -21,6 +21,7 @@ type Batting struct {
 	GroundOuts, FlyOuts, PopOuts   int
 	GIDP                           int
 	HitByPitch                     int
+	IntentionalWalks               int
 	OnBase                         int
 	SacrificeBunts                 int
 	SacrificeFlys                  int
@@ -28,6 +29,7 @@ type Batting struct {
 	FieldersChoice                 int
 	ReachedOnK                     int
 	RE24                           float64
+	WOBA, WRAA, WRC                float64
 	LineDriveOuts                  int
 	LOPH                           int
 	FoulBunts                      int
@@ -81,6 +83,9 @@ func (b *Batting) Record(state *game.State) (teamLOB int) {
 			fallthrough
 		case game.WalkPassedBall:
 			b.Walks++
+		case game.IntentionalWalk:
+			b.Walks++
+			b.IntentionalWalks++
 		case game.GroundOut:
 			b.GroundOuts++
 		case game.FlyOut:
@@ -89,6 +94,8 @@ func (b *Batting) Record(state *game.State) (teamLOB int) {
 			} else {
 				b.FlyOuts++
 			}
+		case game.InfieldFly:
+			b.PopOuts++
 		case game.HitByPitch:
 			b.HitByPitch++
 		case game.ReachedOnError:
@@ -116,7 +123,7 @@ func (b *Batting) Record(state *game.State) (teamLOB int) {
 				b.LineDriveOuts++
 			}
 		}
-		if state.Play.IsHit() || state.Play.Is(game.Walk, game.WalkPickedOff, game.WalkWildPitch, game.WalkPassedBall, game.HitByPitch) {
+		if state.Play.IsHit() || state.Play.Is(game.Walk, game.WalkPickedOff, game.WalkWildPitch, game.WalkPassedBall, game.IntentionalWalk, game.HitByPitch) {
 			b.OnBase++
 		}
 		if state.Modifiers.Contains(game.SacrificeHit) {
@@ -0,0 +1,176 @@
+package stats
+
+import (
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+type Catching struct {
+	PlayerData     `mapstructure:",squash"`
+	Pitches        int
+	StolenBases    int
+	CaughtStealing int
+	CSPct          float64
+	PassedBalls    int
+	WildPitches    int
+	PickedOff      int
+}
+
+// Battery is the pitcher-catcher split.  The catcher is empty when the
+// game has no defense line.
+type Battery struct {
+	Team           string
+	Pitcher        string
+	Catcher        string
+	Pitches        int
+	StealAttempts  int
+	StolenBases    int
+	CaughtStealing int
+	PickedOff      int
+	WildPitches    int
+	PassedBalls    int
+	WPRate         float64
+	PBRate         float64
+}
+
+type batteryKey struct {
+	pitcher, catcher game.PlayerID
+}
+
+func (c *Catching) Update() {
+	c.PlayerData.Update()
+	c.CSPct = 0
+	if c.StolenBases+c.CaughtStealing > 0 {
+		c.CSPct = float64(c.CaughtStealing) / float64(c.StolenBases+c.CaughtStealing)
+	}
+}
+
+func (b *Battery) Update() {
+	b.WPRate = 0
+	b.PBRate = 0
+	if b.Pitches > 0 {
+		b.WPRate = 100 * float64(b.WildPitches) / float64(b.Pitches)
+		b.PBRate = 100 * float64(b.PassedBalls) / float64(b.Pitches)
+	}
+}
+
+func (stats *TeamStats) GetCatching(catcher game.PlayerID) *Catching {
+	c := stats.Catching[catcher]
+	if c == nil {
+		c = &Catching{
+			PlayerData: NewPlayerData(stats.Team.Name, stats.Team.GetPlayer(catcher)),
+		}
+		stats.Catching[catcher] = c
+		stats.Catchers = append(stats.Catchers, catcher)
+	}
+	return c
+}
+
+func (stats *TeamStats) GetBattery(pitcher, catcher game.PlayerID) *Battery {
+	key := batteryKey{pitcher, catcher}
+	b := stats.Batteries[key]
+	if b == nil {
+		b = &Battery{
+			Team:    stats.Team.Name,
+			Pitcher: stats.Team.GetPlayer(pitcher).NameOrNumber(),
+		}
+		if catcher != "" {
+			b.Catcher = stats.Team.GetPlayer(catcher).NameOrNumber()
+		}
+		stats.Batteries[key] = b
+		stats.batteryKeys = append(stats.batteryKeys, key)
+	}
+	return b
+}
+
+func (stats *TeamStats) GetCatchingData() *dataframe.Data {
+	dat := newData("CAT")
+	var idx *dataframe.Index
+	for _, catcher := range stats.Catchers {
+		idx = dat.AppendStruct(idx, stats.Catching[catcher])
+	}
+	return dat
+}
+
+func (stats *TeamStats) GetBatteryData() *dataframe.Data {
+	dat := newData("BATTERY")
+	var idx *dataframe.Index
+	for _, key := range stats.batteryKeys {
+		idx = dat.AppendStruct(idx, stats.Batteries[key])
+	}
+	return dat
+}
+
+// RecordCatching records the catcher and battery stats for the fielding
+// team.
+func (stats *TeamStats) RecordCatching(g *game.Game, state *game.State) {
+	if state.Pitcher == "" {
+		return
+	}
+	catcherID := state.Defense[1]
+	battery := stats.GetBattery(state.Pitcher, catcherID)
+	var catcher *Catching
+	if catcherID != "" {
+		catcher = stats.GetCatching(catcherID)
+		catcher.GameAppearances[g.ID] = true
+	} else {
+		// keep the counts in a throwaway record
+		catcher = &Catching{}
+	}
+	pitches := GetPitchCount(state)
+	battery.Pitches += pitches
+	catcher.Pitches += pitches
+	steals := 0
+	for _, adv := range state.Advances {
+		if adv.Steal {
+			steals++
+		}
+		if adv.WildPitch {
+			battery.WildPitches++
+			catcher.WildPitches++
+		}
+		if adv.PassedBall {
+			battery.PassedBalls++
+			catcher.PassedBalls++
+		}
+	}
+	battery.StolenBases += steals
+	battery.StealAttempts += steals
+	catcher.StolenBases += steals
+	if state.Play.Is(game.WildPitch, game.WalkWildPitch, game.StrikeOutWildPitch) {
+		battery.WildPitches++
+		catcher.WildPitches++
+	}
+	if state.Play.Is(game.PassedBall, game.WalkPassedBall, game.StrikeOutPassedBall) {
+		battery.PassedBalls++
+		catcher.PassedBalls++
+	}
+	if state.Play.Is(game.CaughtStealing, game.StrikeOutCaughtStealing) && !state.NotOutOnPlay {
+		battery.StealAttempts++
+		battery.CaughtStealing++
+		catcher.CaughtStealing++
+	}
+	if state.Play.Is(game.PickedOff, game.WalkPickedOff, game.StrikeOutPickedOff) && !state.NotOutOnPlay {
+		battery.PickedOff++
+		if len(state.Play.Fielders) > 0 && state.Play.Fielders[0] == 2 {
+			catcher.PickedOff++
+		}
+	}
+}
+
+// GetPitchCount returns the pitches thrown during the plate appearance,
+// counted once when the plate appearance or inning ends.
+func GetPitchCount(state *game.State) int {
+	if !(state.Complete || state.Outs == 3) {
+		return 0
+	}
+	known, _, balls, strikes := state.Pitches.Count()
+	if !known {
+		return 0
+	}
+	n := balls + strikes
+	if last := state.Pitches.Last(); last == 'H' || last == 'X' {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatching(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 10
+defense 10 at 1 12 at 2
+1 1 BX S8/G6
+... B SB2
+... C CS3(25)
+2 2 BBB WP
+... X 63/G6
+3 3 BB PB
+... SSS K
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	gs := NewGameStats(nil)
+	assert.NoError(gs.Read(g))
+	ts := gs.TeamStats["b"]
+	c := ts.Catching["12"]
+	if assert.NotNil(c) {
+		assert.Equal(1, c.StolenBases)
+		assert.Equal(1, c.CaughtStealing)
+		assert.Equal(1, c.PassedBalls)
+		assert.Equal(1, c.WildPitches)
+		assert.Equal(ts.Pitching["10"].Pitches, c.Pitches)
+	}
+	b := ts.Batteries[batteryKey{"10", "12"}]
+	if assert.NotNil(b) {
+		assert.Equal(2, b.StealAttempts)
+		assert.Equal(ts.Pitching["10"].Pitches, b.Pitches)
+	}
+	assert.Equal(1, gs.GetCatchingData().RowCount())
+	assert.Equal(1, gs.GetBatteryData().RowCount())
+}
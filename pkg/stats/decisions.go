@@ -0,0 +1,418 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+type Decision string
+
+const (
+	SacBunt    = Decision("Sac bunt")
+	BuntForHit = Decision("Bunt hit")
+	Steal2nd   = Decision("Steal 2nd")
+	Steal3rd   = Decision("Steal 3rd")
+)
+
+var Decisions = []Decision{SacBunt, BuntForHit, Steal2nd, Steal3rd}
+
+// BreakEven is the success rate at which a decision is worth the same
+// as not making it.
+type BreakEven struct {
+	Decision
+	Outs      int
+	Bases     OccupiedBases
+	RE        float64
+	Success   float64
+	Failure   float64
+	BreakEven float64
+}
+
+// DecisionAttempt is a bunt or steal that happened in a game.
+type DecisionAttempt struct {
+	Decision
+	*BreakEven
+	GameID  string
+	State   *game.State
+	Team    string
+	Player  game.PlayerID
+	Success bool
+}
+
+type DecisionAnalysis struct {
+	RE       RunExpectancy
+	Attempts []*DecisionAttempt
+
+	breakEvens map[string]*BreakEven
+	players    map[playerKey]*game.Player
+}
+
+type situation struct {
+	outs  int
+	bases [3]bool
+	runs  int
+}
+
+func (s situation) getValue(re RunExpectancy) float64 {
+	if s.outs >= 3 {
+		return float64(s.runs)
+	}
+	k := []rune{'_', '_', '_'}
+	for i, c := range "123" {
+		if s.bases[i] {
+			k[2-i] = c
+		}
+	}
+	return re.GetExpectedRuns(s.outs, OccupiedBases(k)) + float64(s.runs)
+}
+
+// advanceAll moves every runner up one base.
+func (s situation) advanceAll() situation {
+	if s.bases[2] {
+		s.runs++
+	}
+	s.bases[2], s.bases[1], s.bases[0] = s.bases[1], s.bases[0], false
+	return s
+}
+
+func (s situation) hasRunners() bool {
+	return s.bases[0] || s.bases[1] || s.bases[2]
+}
+
+// getOutcomes returns the situations after the decision succeeds and fails,
+// or false if the decision cannot be made.
+func getOutcomes(d Decision, s situation) (success, failure situation, ok bool) {
+	success, failure = s, s
+	switch d {
+	case Steal2nd:
+		if !s.bases[0] || s.bases[1] {
+			return
+		}
+		success.bases[0], success.bases[1] = false, true
+		failure.bases[0] = false
+		failure.outs++
+	case Steal3rd:
+		if !s.bases[1] || s.bases[2] {
+			return
+		}
+		success.bases[1], success.bases[2] = false, true
+		failure.bases[1] = false
+		failure.outs++
+	case SacBunt:
+		if !s.hasRunners() || s.outs == 2 {
+			return
+		}
+		success = s.advanceAll()
+		success.outs++
+		// the lead runner is thrown out and the batter reaches
+		lead := 2
+		for !s.bases[lead] {
+			lead--
+		}
+		failure = s
+		failure.bases[lead] = false
+		failure = failure.advanceAll()
+		failure.bases[0] = true
+		failure.outs++
+	case BuntForHit:
+		success = s.advanceAll()
+		success.bases[0] = true
+		failure = s.advanceAll()
+		failure.outs++
+		if failure.outs == 3 {
+			failure.runs = 0
+		}
+	default:
+		return
+	}
+	return success, failure, true
+}
+
+func NewDecisionAnalysis(re RunExpectancy) *DecisionAnalysis {
+	da := &DecisionAnalysis{
+		RE:         re,
+		breakEvens: map[string]*BreakEven{},
+		players:    map[playerKey]*game.Player{},
+	}
+	for _, d := range Decisions {
+		for outs := 0; outs < 3; outs++ {
+			for _, bases := range OccupedBasesValues {
+				s := situation{outs: outs}
+				s.bases[0] = bases[2] == '1'
+				s.bases[1] = bases[1] == '2'
+				s.bases[2] = bases[0] == '3'
+				success, failure, ok := getOutcomes(d, s)
+				if !ok {
+					continue
+				}
+				be := &BreakEven{
+					Decision: d,
+					Outs:     outs,
+					Bases:    bases,
+					RE:       s.getValue(re),
+					Success:  success.getValue(re),
+					Failure:  failure.getValue(re),
+				}
+				switch {
+				case be.Success <= be.Failure || be.Success <= be.RE:
+					be.BreakEven = 1
+				case be.Failure >= be.RE:
+					be.BreakEven = 0
+				default:
+					be.BreakEven = (be.RE - be.Failure) / (be.Success - be.Failure)
+				}
+				da.breakEvens[getBreakEvenKey(d, outs, bases)] = be
+			}
+		}
+	}
+	return da
+}
+
+func getBreakEvenKey(d Decision, outs int, bases OccupiedBases) string {
+	return fmt.Sprintf("%s/%d/%s", d, outs, bases)
+}
+
+func (da *DecisionAnalysis) GetBreakEven(d Decision, outs int, bases OccupiedBases) *BreakEven {
+	return da.breakEvens[getBreakEvenKey(d, outs, bases)]
+}
+
+func isBunt(state *game.State) bool {
+	switch state.Modifiers.Trajectory() {
+	case game.Bunt, game.BuntGrounder, game.BuntPopup:
+		return true
+	}
+	return false
+}
+
+func (da *DecisionAnalysis) Read(g *game.Game) {
+	for _, state := range g.GetStates() {
+		team := g.Home
+		if state.Top() {
+			team = g.Visitor
+		}
+		last := state.LastState
+		if last == nil || last.Outs == 3 {
+			last = &game.State{}
+		}
+		add := func(d Decision, player game.PlayerID, success bool) {
+			be := da.GetBreakEven(d, last.Outs, GetOccupiedBases(last))
+			if be == nil {
+				return
+			}
+			da.players[playerKey{team.Name, player}] = team.GetPlayer(player)
+			da.Attempts = append(da.Attempts, &DecisionAttempt{
+				Decision:  d,
+				BreakEven: be,
+				GameID:    g.ID,
+				State:     state,
+				Team:      team.Name,
+				Player:    player,
+				Success:   success,
+			})
+		}
+		for _, adv := range state.Advances {
+			if adv.Steal && adv.To == "2" {
+				add(Steal2nd, adv.Runner, true)
+			}
+			if adv.Steal && adv.To == "3" {
+				add(Steal3rd, adv.Runner, true)
+			}
+		}
+		if state.Play.Is(game.CaughtStealing, game.StrikeOutCaughtStealing) {
+			switch state.CaughtStealingBase {
+			case "2":
+				add(Steal2nd, state.CaughtStealingRunner, state.NotOutOnPlay)
+			case "3":
+				add(Steal3rd, state.CaughtStealingRunner, state.NotOutOnPlay)
+			}
+		}
+		if state.Complete && state.Play.IsBallInPlay() && isBunt(state) {
+			batterSafe := state.Play.IsHit() || state.Play.Is(game.ReachedOnError)
+			runnerOut := false
+			for _, adv := range state.Advances {
+				if adv.From != "B" && adv.Out {
+					runnerOut = true
+				}
+			}
+			hasRunners := last.Runners != [3]game.PlayerID{}
+			if hasRunners && last.Outs < 2 && !(batterSafe && !state.Modifiers.Contains(game.SacrificeHit)) {
+				add(SacBunt, state.Batter, !runnerOut)
+			} else {
+				add(BuntForHit, state.Batter, batterSafe)
+			}
+		}
+	}
+}
+
+type decisionKey struct {
+	team   string
+	player game.PlayerID
+	Decision
+}
+
+type decisionRate struct {
+	attempts, successes int
+	breakEven           float64
+}
+
+func (r *decisionRate) getRate() float64 {
+	if r == nil || r.attempts == 0 {
+		return 0
+	}
+	return float64(r.successes) / float64(r.attempts)
+}
+
+func (da *DecisionAnalysis) getRates(byPlayer bool) (map[decisionKey]*decisionRate, []decisionKey) {
+	rates := map[decisionKey]*decisionRate{}
+	var keys []decisionKey
+	for _, att := range da.Attempts {
+		key := decisionKey{team: att.Team, Decision: att.Decision}
+		if byPlayer {
+			key.player = att.Player
+		}
+		r := rates[key]
+		if r == nil {
+			r = &decisionRate{}
+			rates[key] = r
+			keys = append(keys, key)
+		}
+		r.attempts++
+		if att.Success {
+			r.successes++
+		}
+		r.breakEven += att.BreakEven.BreakEven
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].team != keys[j].team {
+			return keys[i].team < keys[j].team
+		}
+		if keys[i].Decision != keys[j].Decision {
+			return keys[i].Decision < keys[j].Decision
+		}
+		return keys[i].player < keys[j].player
+	})
+	return rates, keys
+}
+
+// GetExpectedValue returns the expected change in runs of an attempt given
+// the success rate of the team.
+func (da *DecisionAnalysis) GetExpectedValue(att *DecisionAttempt) float64 {
+	rates, _ := da.getRates(false)
+	return getExpectedValue(rates, att)
+}
+
+func getExpectedValue(rates map[decisionKey]*decisionRate, att *DecisionAttempt) float64 {
+	p := rates[decisionKey{team: att.Team, Decision: att.Decision}].getRate()
+	return p*att.BreakEven.Success + (1-p)*att.BreakEven.Failure - att.BreakEven.RE
+}
+
+func (da *DecisionAnalysis) GetBreakEvenData() *dataframe.Data {
+	var (
+		decision  = dataframe.NewColumn("Decision", "%-9s", dataframe.EmptyStrings)
+		outs      = dataframe.NewColumn("O", "%d", dataframe.EmptyInts)
+		bases     = dataframe.NewColumn("Runr", "%4s", dataframe.EmptyStrings)
+		re        = dataframe.NewColumn("RE", "%5.3f", dataframe.EmptyFloats)
+		success   = dataframe.NewColumn("Success", "%7.3f", dataframe.EmptyFloats)
+		failure   = dataframe.NewColumn("Failure", "%7.3f", dataframe.EmptyFloats)
+		breakEven = dataframe.NewColumn("BE%", "%5.3f", dataframe.EmptyFloats)
+	)
+	for _, d := range Decisions {
+		for o := 0; o < 3; o++ {
+			for _, b := range OccupedBasesValues {
+				be := da.GetBreakEven(d, o, b)
+				if be == nil {
+					continue
+				}
+				decision.AppendString(string(d))
+				outs.AppendInt(o)
+				bases.AppendString(string(b))
+				re.AppendFloat(be.RE)
+				success.AppendFloat(be.Success)
+				failure.AppendFloat(be.Failure)
+				breakEven.AppendFloat(be.BreakEven)
+			}
+		}
+	}
+	return &dataframe.Data{
+		Name:    "Break Even",
+		Columns: []*dataframe.Column{decision, outs, bases, re, success, failure, breakEven},
+	}
+}
+
+// GetSuccessData returns the observed success rate of each decision per
+// team, or per player, with the average break even rate of the attempts.
+func (da *DecisionAnalysis) GetSuccessData(byPlayer bool) *dataframe.Data {
+	var (
+		team      = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		player    = dataframe.NewColumn("Player", "%-12s", dataframe.EmptyStrings)
+		decision  = dataframe.NewColumn("Decision", "%-9s", dataframe.EmptyStrings)
+		attempts  = dataframe.NewColumn("Att", "%3d", dataframe.EmptyInts)
+		successes = dataframe.NewColumn("Succ", "%4d", dataframe.EmptyInts)
+		rate      = dataframe.NewColumn("Rate", "%5.3f", dataframe.EmptyFloats)
+		breakEven = dataframe.NewColumn("BE%", "%5.3f", dataframe.EmptyFloats)
+	)
+	rates, keys := da.getRates(byPlayer)
+	for _, key := range keys {
+		r := rates[key]
+		team.AppendString(key.team)
+		if byPlayer {
+			player.AppendString(da.players[playerKey{key.team, key.player}].NameOrNumber())
+		}
+		decision.AppendString(string(key.Decision))
+		attempts.AppendInt(r.attempts)
+		successes.AppendInt(r.successes)
+		rate.AppendFloat(r.getRate())
+		breakEven.AppendFloat(r.breakEven / float64(r.attempts))
+	}
+	dat := &dataframe.Data{Name: "Success"}
+	dat.Columns = append(dat.Columns, team)
+	if byPlayer {
+		dat.Columns = append(dat.Columns, player)
+	}
+	dat.Columns = append(dat.Columns, decision, attempts, successes, rate, breakEven)
+	return dat
+}
+
+// GetAttemptsData returns the attempts, with the expected value of each
+// using the team's success rate.  If negative is true, only the
+// attempts with a negative expected value are included.
+func (da *DecisionAnalysis) GetAttemptsData(negative bool) *dataframe.Data {
+	var (
+		gameID   = dataframe.NewColumn("Game", "%-10s", dataframe.EmptyStrings)
+		inning   = dataframe.NewColumn("Inn", "%4s", dataframe.EmptyStrings)
+		team     = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		player   = dataframe.NewColumn("Player", "%-12s", dataframe.EmptyStrings)
+		decision = dataframe.NewColumn("Decision", "%-9s", dataframe.EmptyStrings)
+		bases    = dataframe.NewColumn("Runr", "%4s", dataframe.EmptyStrings)
+		result   = dataframe.NewColumn("Result", "%-6s", dataframe.EmptyStrings)
+		be       = dataframe.NewColumn("BE%", "%5.3f", dataframe.EmptyFloats)
+		ev       = dataframe.NewColumn("EV", "% 6.3f", dataframe.EmptyFloats)
+	)
+	rates, _ := da.getRates(false)
+	for _, att := range da.Attempts {
+		value := getExpectedValue(rates, att)
+		if negative && value >= 0 {
+			continue
+		}
+		gameID.AppendString(att.GameID)
+		inning.AppendString(fmt.Sprintf("%c%d.%d", att.State.Half[0], att.State.InningNumber, att.BreakEven.Outs))
+		team.AppendString(att.Team)
+		player.AppendString(da.players[playerKey{att.Team, att.Player}].NameOrNumber())
+		decision.AppendString(string(att.Decision))
+		bases.AppendString(string(att.BreakEven.Bases))
+		if att.Success {
+			result.AppendString("safe")
+		} else {
+			result.AppendString("failed")
+		}
+		be.AppendFloat(att.BreakEven.BreakEven)
+		ev.AppendFloat(value)
+	}
+	return &dataframe.Data{
+		Name:    "Attempts",
+		Columns: []*dataframe.Column{gameID, inning, team, player, decision, bases, result, be, ev},
+	}
+}
@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecisions(t *testing.T) {
+	assert := assert.New(t)
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	da := NewDecisionAnalysis(re)
+	be := da.GetBreakEven(Steal2nd, 0, RunnerOnFirst)
+	if assert.NotNil(be) {
+		assert.Greater(be.BreakEven, 0.5)
+		assert.Less(be.BreakEven, 1.0)
+		assert.InDelta(be.RE, be.BreakEven*be.Success+(1-be.BreakEven)*be.Failure, 0.0001)
+	}
+	assert.Nil(da.GetBreakEven(Steal2nd, 0, RunnerOnSecond))
+	assert.Nil(da.GetBreakEven(SacBunt, 2, RunnerOnFirst))
+	assert.NotNil(da.GetBreakEven(BuntForHit, 2, BasesEmpty))
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X S8/G6
+2 2 B SB2
+... X 53/G5/B/SH 2-3
+3 3 X 8/F8
+4 4 X S8/G6
+5 5 C CS2(26)
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	da.Read(g)
+	if assert.Len(da.Attempts, 3) {
+		assert.Equal(Steal2nd, da.Attempts[0].Decision)
+		assert.True(da.Attempts[0].Success)
+		assert.Equal(SacBunt, da.Attempts[1].Decision)
+		assert.True(da.Attempts[1].Success)
+		assert.Equal(Steal2nd, da.Attempts[2].Decision)
+		assert.False(da.Attempts[2].Success)
+	}
+	assert.Equal(2, da.GetSuccessData(false).RowCount())
+	assert.Equal(3, da.GetAttemptsData(false).RowCount())
+	assert.Greater(da.GetBreakEvenData().RowCount(), 0)
+}
+
+func TestDecisionsSameNumber(t *testing.T) {
+	assert := assert.New(t)
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 7 X S8/G6
+2 2 B SB2
+homeplays
+1 7 X S8/G6
+2 2 C CS2(26)
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	g.Visitor.GetPlayer("7").Name = "Alice"
+	g.Home.GetPlayer("7").Name = "Beth"
+	da := NewDecisionAnalysis(re)
+	da.Read(g)
+	dat := da.GetAttemptsData(false)
+	if assert.Equal(2, dat.RowCount()) {
+		idx := dat.GetIndex()
+		for row := 0; row < 2; row++ {
+			if idx.GetString(row, "Team") == "a" {
+				assert.Equal("Alice", idx.GetString(row, "Player"))
+			} else {
+				assert.Equal("Beth", idx.GetString(row, "Player"))
+			}
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package stats
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+// DefensePlay is the run value of a play credited to a fielder.  Runs is
+// the runs saved, so errors are negative.
+type DefensePlay struct {
+	Team     string
+	Player   game.PlayerID
+	Name     string
+	Position int
+	Month    string
+	GameID   string
+	Kind     string
+	Runs     float64
+}
+
+const (
+	DefenseError      = "E"
+	DefenseAlt        = "Alt"
+	DefenseDoublePlay = "DP"
+)
+
+// Defense attributes run value to fielders for errors, alternative plays
+// and double plays.  An error is priced as the change in run expectancy
+// of the play against the out that would have been made without the
+// error.  An error on a play with an alternative is counted, but its run
+// value comes from the alternative.
+type Defense struct {
+	RE    RunExpectancy
+	Plays []*DefensePlay
+}
+
+func NewDefense(re RunExpectancy) *Defense {
+	return &Defense{RE: re}
+}
+
+func (d *Defense) Read(g *game.Game) {
+	for _, state := range g.GetStates() {
+		team := g.Visitor
+		if state.Top() {
+			team = g.Home
+		}
+		add := func(kind string, position int, player game.PlayerID, runs float64) {
+			var name string
+			switch {
+			case player != "":
+				name = team.GetPlayer(player).NameOrNumber()
+			case position > 0:
+				// without a defense line only the position is known
+				name = game.FielderNames[position-1]
+			default:
+				return
+			}
+			d.Plays = append(d.Plays, &DefensePlay{
+				Team:     team.Name,
+				Player:   player,
+				Name:     name,
+				Position: position,
+				Month:    g.GetStateDate(state).Format("2006-01"),
+				GameID:   g.ID,
+				Kind:     kind,
+				Runs:     runs,
+			})
+		}
+		alt := g.GetAlternativeState(state)
+		for _, e := range d.getErrors(state, alt == nil) {
+			add(DefenseError, e.Fielder, state.GetErrorPlayer(e.FieldingError), e.runs)
+		}
+		if alt != nil {
+			credits := getAltCredit(alt)
+			_, _, _, change := GetExpectedRunsChange(d.RE, alt)
+			_, _, _, originalChange := GetExpectedRunsChange(d.RE, state)
+			for _, player := range credits {
+				// credits for runners are not defense
+				if pos := getPosition(state, player); pos > 0 {
+					add(DefenseAlt, pos, player, (change-originalChange)/float64(len(credits)))
+				}
+			}
+		}
+		if state.Play.Is(game.DoublePlay) {
+			fielders := getDoublePlayFielders(state.PlayCode)
+			runs := d.getDoublePlayValue(state)
+			for _, pos := range fielders {
+				add(DefenseDoublePlay, pos, state.Defense[pos-1], runs/float64(len(fielders)))
+			}
+		}
+	}
+}
+
+type pricedError struct {
+	game.FieldingError
+	runs float64
+}
+
+func (d *Defense) getValue(s situation) float64 {
+	return s.getValue(d.RE)
+}
+
+// getSituation returns the outs, runners and runs scored after state.
+func getSituation(state *game.State) situation {
+	var s situation
+	if state == nil {
+		return s
+	}
+	s.outs = state.Outs
+	s.runs = len(state.ScoringRunners)
+	for i, runner := range state.Runners {
+		s.bases[i] = runner != ""
+	}
+	return s
+}
+
+// getErrors returns the errors on the play, and if price is true, the
+// runs saved (which will be negative) of each.
+func (d *Defense) getErrors(state *game.State, price bool) []pricedError {
+	before := getSituation(state.LastState)
+	before.runs = 0
+	after := getSituation(state)
+	var errs []pricedError
+	addError := func(e game.FieldingError, implied situation, ok bool) {
+		pe := pricedError{FieldingError: e}
+		if price && ok {
+			if implied.outs >= 3 {
+				implied.runs = 0
+			}
+			pe.runs = d.getValue(implied) - d.getValue(after)
+		}
+		errs = append(errs, pe)
+	}
+	// the out that would have been made without the error
+	removeRunner := func(runner game.PlayerID) situation {
+		implied := after
+		implied.outs++
+		for i, r := range state.Runners {
+			if r == runner {
+				implied.bases[i] = false
+			}
+		}
+		if slices.Contains(state.ScoringRunners, runner) {
+			implied.runs--
+		}
+		return implied
+	}
+	switch state.Play.Type {
+	case game.ReachedOnError:
+		addError(state.Play.FieldingError, removeRunner(state.Batter), true)
+	case game.FoulFlyError:
+		implied := after
+		implied.outs++
+		addError(state.Play.FieldingError, implied, true)
+	case game.CatcherInterference:
+		addError(state.Play.FieldingError, before, true)
+	case game.CaughtStealing, game.StrikeOutCaughtStealing:
+		if state.NotOutOnPlay && state.Play.FieldingError.IsFieldingError() {
+			addError(state.Play.FieldingError, removeRunner(state.CaughtStealingRunner), true)
+		}
+	case game.PickedOff, game.WalkPickedOff, game.StrikeOutPickedOff:
+		if state.NotOutOnPlay && state.Play.FieldingError.IsFieldingError() {
+			addError(state.Play.FieldingError, removeRunner(state.PickedOffRunner), true)
+		}
+	}
+	for _, adv := range state.Advances {
+		if !adv.IsFieldingError() || adv.Out {
+			continue
+		}
+		// without the error the runner would have stopped a base short
+		implied := after
+		to := game.BaseNumber[adv.To]
+		ok := to > 0 && !implied.bases[to-1]
+		if ok {
+			if adv.To == "H" {
+				implied.runs--
+			} else {
+				implied.bases[to] = false
+			}
+			implied.bases[to-1] = true
+		}
+		addError(adv.FieldingError, implied, ok)
+	}
+	for _, se := range state.ScorerErrors {
+		if se.Added {
+			addError(se.FieldingError, after, false)
+		}
+	}
+	return errs
+}
+
+// getDoublePlayValue returns the runs saved by the double play compared
+// to a single force out with the batter safe at first.
+func (d *Defense) getDoublePlayValue(state *game.State) float64 {
+	after := getSituation(state)
+	implied := after
+	implied.outs--
+	implied.bases[0] = true
+	return d.getValue(implied) - d.getValue(after)
+}
+
+// getDoublePlayFielders returns the positions of the fielders in a double
+// play code like 64(1)3/GDP.
+func getDoublePlayFielders(code string) []int {
+	code, _, _ = strings.Cut(code, "/")
+	var fielders []int
+	paren := 0
+	for _, c := range code {
+		switch {
+		case c == '(':
+			paren++
+		case c == ')':
+			paren--
+		case paren == 0 && c >= '1' && c <= '9':
+			pos := int(c - '0')
+			if !slices.Contains(fielders, pos) {
+				fielders = append(fielders, pos)
+			}
+		}
+	}
+	return fielders
+}
+
+func getPosition(state *game.State, player game.PlayerID) int {
+	for i, p := range state.Defense {
+		if p == player {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+type defenseKey struct {
+	team     string
+	player   game.PlayerID
+	position int
+	month    string
+}
+
+type defenseValue struct {
+	name                               string
+	errors, alts, doublePlays          int
+	errorRuns, altRuns, doublePlayRuns float64
+}
+
+func (v *defenseValue) getRuns() float64 {
+	return v.errorRuns + v.altRuns + v.doublePlayRuns
+}
+
+func (d *Defense) group(byPosition, byMonth bool) (map[defenseKey]*defenseValue, []defenseKey) {
+	values := map[defenseKey]*defenseValue{}
+	var keys []defenseKey
+	for _, play := range d.Plays {
+		key := defenseKey{team: play.Team, player: play.Player}
+		if byPosition || play.Player == "" {
+			key.position = play.Position
+		}
+		if byMonth {
+			key.month = play.Month
+		}
+		v := values[key]
+		if v == nil {
+			v = &defenseValue{name: play.Name}
+			values[key] = v
+			keys = append(keys, key)
+		}
+		switch play.Kind {
+		case DefenseError:
+			v.errors++
+			v.errorRuns += play.Runs
+		case DefenseAlt:
+			v.alts++
+			v.altRuns += play.Runs
+		case DefenseDoublePlay:
+			v.doublePlays++
+			v.doublePlayRuns += play.Runs
+		}
+	}
+	return values, keys
+}
+
+// GetData returns the runs saved by each fielder, best first, and if
+// byPosition is true, by each fielder at each position.
+func (d *Defense) GetData(byPosition bool) *dataframe.Data {
+	var (
+		name     = dataframe.NewColumn("Name", "%-12s", dataframe.EmptyStrings)
+		team     = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		position = dataframe.NewColumn("Pos", "%3s", dataframe.EmptyStrings)
+		errors   = dataframe.NewColumn("E", "%2d", dataframe.EmptyInts)
+		eruns    = dataframe.NewColumn("ERuns", "% 6.2f", dataframe.EmptyFloats)
+		alts     = dataframe.NewColumn("Alt", "%3d", dataframe.EmptyInts)
+		altRuns  = dataframe.NewColumn("AltRuns", "% 7.2f", dataframe.EmptyFloats)
+		dps      = dataframe.NewColumn("DP", "%2d", dataframe.EmptyInts)
+		dpRuns   = dataframe.NewColumn("DPRuns", "% 6.2f", dataframe.EmptyFloats)
+		runs     = dataframe.NewColumn("RS", "% 6.2f", dataframe.EmptyFloats)
+	)
+	runs.Summary = dataframe.Sum
+	values, keys := d.group(byPosition, false)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return values[keys[i]].getRuns() > values[keys[j]].getRuns()
+	})
+	for _, key := range keys {
+		v := values[key]
+		name.AppendString(v.name)
+		team.AppendString(key.team)
+		if key.position > 0 {
+			position.AppendString(game.FielderNames[key.position-1])
+		} else {
+			position.AppendString("")
+		}
+		errors.AppendInt(v.errors)
+		eruns.AppendFloat(v.errorRuns)
+		alts.AppendInt(v.alts)
+		altRuns.AppendFloat(v.altRuns)
+		dps.AppendInt(v.doublePlays)
+		dpRuns.AppendFloat(v.doublePlayRuns)
+		runs.AppendFloat(v.getRuns())
+	}
+	dat := &dataframe.Data{Name: "Defensive Runs Saved"}
+	dat.Columns = append(dat.Columns, name, team)
+	if byPosition {
+		dat.Columns = append(dat.Columns, position)
+	}
+	dat.Columns = append(dat.Columns, errors, eruns, alts, altRuns, dps, dpRuns, runs)
+	return dat
+}
+
+// GetMonthlyData returns the runs saved by each fielder in each month.
+func (d *Defense) GetMonthlyData() *dataframe.Data {
+	values, keys := d.group(false, true)
+	var (
+		months   []string
+		fielders []defenseKey
+		names    = map[defenseKey]string{}
+	)
+	for _, key := range keys {
+		if !slices.Contains(months, key.month) {
+			months = append(months, key.month)
+		}
+		// keep the position of fielders without a defense line
+		fielder := key
+		fielder.month = ""
+		if _, ok := names[fielder]; !ok {
+			names[fielder] = values[key].name
+			fielders = append(fielders, fielder)
+		}
+	}
+	sort.Strings(months)
+	var (
+		name    = dataframe.NewColumn("Name", "%-12s", dataframe.EmptyStrings)
+		team    = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		columns = make([]*dataframe.Column, len(months))
+	)
+	for i, month := range months {
+		columns[i] = dataframe.NewColumn(month, "% 7.2f", dataframe.EmptyFloats)
+		columns[i].Summary = dataframe.Sum
+	}
+	for _, fielder := range fielders {
+		name.AppendString(names[fielder])
+		team.AppendString(fielder.team)
+		for i, month := range months {
+			key := fielder
+			key.month = month
+			if v := values[key]; v != nil {
+				columns[i].AppendFloat(v.getRuns())
+			} else {
+				columns[i].AppendFloat(0)
+			}
+		}
+	}
+	return &dataframe.Data{
+		Name:    "Defensive Runs Saved by Month",
+		Columns: append([]*dataframe.Column{name, team}, columns...),
+	}
+}
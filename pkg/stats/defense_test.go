@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefense(t *testing.T) {
+	assert := assert.New(t)
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+defense 1 at 1 2 at 2 3 at 3 4 at 4 5 at 5 6 at 6 7 at 7 8 at 8 9 at 9
+1 1 X E6/G6 B-1
+2 2 X 64(1)3/GDP
+3 3 X S8/G6
+4 4 X 9/F9
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	d := NewDefense(re)
+	d.Read(g)
+	if !assert.Len(d.Plays, 4) {
+		return
+	}
+	assert.Equal(DefenseError, d.Plays[0].Kind)
+	assert.Equal(6, d.Plays[0].Position)
+	assert.Less(d.Plays[0].Runs, 0.0)
+	for _, play := range d.Plays[1:] {
+		assert.Equal(DefenseDoublePlay, play.Kind)
+		assert.Greater(play.Runs, 0.0)
+	}
+	assert.Equal([]int{6, 4, 3}, getDoublePlayFielders("64(1)3/GDP"))
+	assert.Equal(game.PlayerID("6"), d.Plays[0].Player)
+	assert.Equal(3, d.GetData(false).RowCount())
+	assert.Equal(3, d.GetMonthlyData().RowCount())
+}
+
+func TestDefenseMonthlyWithoutDefense(t *testing.T) {
+	assert := assert.New(t)
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	d := NewDefense(re)
+	for _, text := range []string{`date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X E6/G6 B-1
+`, `date: 6/4/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X E4/G4 B-1
+2 2 X E6/G6 B-1 1-2
+`} {
+		gf, err := gamefile.ParseString("test.gm", text)
+		if !assert.NoError(err) {
+			return
+		}
+		g, err := game.NewGame(gf)
+		if !assert.NoError(err) {
+			return
+		}
+		d.Read(g)
+	}
+	dat := d.GetMonthlyData()
+	if !assert.Equal(2, dat.RowCount()) {
+		return
+	}
+	assert.Equal(dat.GetColumn("Team").Len(), dat.GetColumn("Name").Len())
+	idx := dat.GetIndex()
+	for row := 0; row < dat.RowCount(); row++ {
+		runs := idx.GetFloat(row, "2022-05") + idx.GetFloat(row, "2022-06")
+		assert.Less(runs, 0.0)
+	}
+}
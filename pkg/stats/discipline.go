@@ -0,0 +1,231 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+// Discipline tracks the batters' and pitchers' approach pitch by pitch.
+type Discipline struct {
+	Us    string
+	NotUs string
+
+	batters  map[playerKey]*BatterDiscipline
+	pitchers map[playerKey]*PitcherDiscipline
+	bkeys    []playerKey
+	pkeys    []playerKey
+}
+
+type playerKey struct {
+	team   string
+	player game.PlayerID
+}
+
+type BatterDiscipline struct {
+	PlayerData
+	PA                 int
+	FirstPitchSwings   int
+	TwoStrikePitches   int
+	TwoStrikeFouls     int
+	StrikeOuts         int
+	CalledThirdStrikes int
+	Pitches            [12]int
+	Swings             [12]int
+}
+
+type PitcherDiscipline struct {
+	PlayerData
+	PA                int
+	FirstPitchStrikes int
+	OhTwo             int
+	OhTwoOuts         int
+}
+
+func NewDiscipline() *Discipline {
+	return &Discipline{
+		batters:  map[playerKey]*BatterDiscipline{},
+		pitchers: map[playerKey]*PitcherDiscipline{},
+	}
+}
+
+func isSwing(p rune) bool {
+	return strings.ContainsRune("SFMXTL", p)
+}
+
+func isStrike(p rune) bool {
+	return strings.ContainsRune("CSFMXTL", p)
+}
+
+func (d *Discipline) Read(g *game.Game) {
+	for _, state := range getBattingStates(g, d.Us, d.NotUs) {
+		if !state.Complete || state.Pitches.IsUnknown() {
+			continue
+		}
+		batting, fielding := g.Home, g.Visitor
+		if state.Top() {
+			batting, fielding = g.Visitor, g.Home
+		}
+		d.record(g, batting, fielding, state)
+	}
+}
+
+func (d *Discipline) getBatter(team *game.Team, batter game.PlayerID) *BatterDiscipline {
+	key := playerKey{team.Name, batter}
+	b := d.batters[key]
+	if b == nil {
+		b = &BatterDiscipline{
+			PlayerData: NewPlayerData(team.Name, team.GetPlayer(batter)),
+		}
+		d.batters[key] = b
+		d.bkeys = append(d.bkeys, key)
+	}
+	return b
+}
+
+func (d *Discipline) getPitcher(team *game.Team, pitcher game.PlayerID) *PitcherDiscipline {
+	key := playerKey{team.Name, pitcher}
+	p := d.pitchers[key]
+	if p == nil {
+		p = &PitcherDiscipline{
+			PlayerData: NewPlayerData(team.Name, team.GetPlayer(pitcher)),
+		}
+		d.pitchers[key] = p
+		d.pkeys = append(d.pkeys, key)
+	}
+	return p
+}
+
+func (d *Discipline) record(g *game.Game, batting, fielding *game.Team, state *game.State) {
+	batter := d.getBatter(batting, state.Batter)
+	batter.GameAppearances[g.ID] = true
+	batter.PA++
+	var pitcher *PitcherDiscipline
+	if state.Pitcher != "" {
+		pitcher = d.getPitcher(fielding, state.Pitcher)
+		pitcher.GameAppearances[g.ID] = true
+		pitcher.PA++
+	}
+	first := true
+	ohTwo := false
+	for i, p := range state.Pitches {
+		if p == '.' || p == '?' {
+			continue
+		}
+		_, _, balls, strikes := state.Pitches[0:i].Count()
+		if balls > 3 || strikes > 2 {
+			break
+		}
+		sit := strikes*4 + balls
+		batter.Pitches[sit]++
+		if isSwing(p) {
+			batter.Swings[sit]++
+		}
+		if first {
+			if isSwing(p) {
+				batter.FirstPitchSwings++
+			}
+			if pitcher != nil && isStrike(p) {
+				pitcher.FirstPitchStrikes++
+			}
+			first = false
+		}
+		if strikes == 2 {
+			batter.TwoStrikePitches++
+			if p == 'F' || p == 'L' {
+				batter.TwoStrikeFouls++
+			}
+		}
+		if balls == 0 && strikes == 2 {
+			ohTwo = true
+		}
+	}
+	if state.IsStrikeOut() {
+		batter.StrikeOuts++
+		if state.Pitches.Last() == 'C' {
+			batter.CalledThirdStrikes++
+		}
+	}
+	if pitcher != nil && ohTwo {
+		pitcher.OhTwo++
+		if adv := state.Advances.From("B"); adv == nil || adv.Out {
+			pitcher.OhTwoOuts++
+		}
+	}
+}
+
+func rate(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func (d *Discipline) GetBatterData() *dataframe.Data {
+	var (
+		name    = dataframe.NewColumn("Name", "%-12s", dataframe.EmptyStrings)
+		team    = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		pa      = dataframe.NewColumn("PA", "%3d", dataframe.EmptyInts)
+		fps     = dataframe.NewColumn("FPSw%", "%5.3f", dataframe.EmptyFloats)
+		foul    = dataframe.NewColumn("2SFoul%", "%7.3f", dataframe.EmptyFloats)
+		calledK = dataframe.NewColumn("CK%", "%5.3f", dataframe.EmptyFloats)
+		swings  [12]*dataframe.Column
+	)
+	for balls := 0; balls <= 3; balls++ {
+		for strikes := 0; strikes <= 2; strikes++ {
+			swings[strikes*4+balls] = dataframe.NewColumn(fmt.Sprintf("%d-%d", balls, strikes),
+				"%5.3f", dataframe.EmptyFloats)
+		}
+	}
+	for _, key := range d.bkeys {
+		b := d.batters[key]
+		name.AppendString(b.Name)
+		team.AppendString(b.Team)
+		pa.AppendInt(b.PA)
+		fps.AppendFloat(rate(b.FirstPitchSwings, b.PA))
+		foul.AppendFloat(rate(b.TwoStrikeFouls, b.TwoStrikePitches))
+		calledK.AppendFloat(rate(b.CalledThirdStrikes, b.StrikeOuts))
+		for i, col := range swings {
+			col.AppendFloat(rate(b.Swings[i], b.Pitches[i]))
+		}
+	}
+	dat := &dataframe.Data{
+		Name:    "Batter Discipline",
+		Columns: []*dataframe.Column{name, team, pa, fps, foul, calledK},
+	}
+	dat.Columns = append(dat.Columns, swings[:]...)
+	idx := dat.GetIndex()
+	return dat.RSort(func(r1, r2 int) bool {
+		return comparePlayers(idx, r1, r2)
+	})
+}
+
+func (d *Discipline) GetPitcherData() *dataframe.Data {
+	var (
+		name  = dataframe.NewColumn("Name", "%-12s", dataframe.EmptyStrings)
+		team  = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		pa    = dataframe.NewColumn("BF", "%3d", dataframe.EmptyInts)
+		fps   = dataframe.NewColumn("FPS%", "%5.3f", dataframe.EmptyFloats)
+		ohTwo = dataframe.NewColumn("0-2", "%3d", dataframe.EmptyInts)
+		conv  = dataframe.NewColumn("0-2Out%", "%7.3f", dataframe.EmptyFloats)
+	)
+	for _, key := range d.pkeys {
+		p := d.pitchers[key]
+		name.AppendString(p.Name)
+		team.AppendString(p.Team)
+		pa.AppendInt(p.PA)
+		fps.AppendFloat(rate(p.FirstPitchStrikes, p.PA))
+		ohTwo.AppendInt(p.OhTwo)
+		conv.AppendFloat(rate(p.OhTwoOuts, p.OhTwo))
+	}
+	dat := &dataframe.Data{
+		Name:    "Pitcher Discipline",
+		Columns: []*dataframe.Column{name, team, pa, fps, ohTwo, conv},
+	}
+	idx := dat.GetIndex()
+	return dat.RSort(func(r1, r2 int) bool {
+		return comparePlayers(idx, r1, r2)
+	})
+}
@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscipline(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 10
+1 1 SCFFC K
+2 2 BSX S8/G6
+3 3 CCBX 63/G6
+4 3 BBBB W 1-2
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	d := NewDiscipline()
+	d.Read(g)
+	b := d.batters[playerKey{"a", "1"}]
+	if assert.NotNil(b) {
+		assert.Equal(1, b.FirstPitchSwings)
+		assert.Equal(3, b.TwoStrikePitches)
+		assert.Equal(2, b.TwoStrikeFouls)
+		assert.Equal(1, b.CalledThirdStrikes)
+		assert.Equal(1, b.Swings[0])
+	}
+	p := d.pitchers[playerKey{"b", "10"}]
+	if assert.NotNil(p) {
+		assert.Equal(4, p.PA)
+		assert.Equal(2, p.FirstPitchStrikes)
+		assert.Equal(2, p.OhTwo)
+		assert.Equal(2, p.OhTwoOuts)
+	}
+	assert.Equal(3, d.GetBatterData().RowCount())
+	assert.Equal(1, d.GetPitcherData().RowCount())
+
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	bc := NewBattingByCount()
+	bc.RE = re
+	bc.Read(g)
+	dat := bc.GetData()
+	assert.NotNil(dat.GetColumn("RV"))
+	assert.Equal(0.0, dat.GetColumn("RV00").GetFloat(0))
+}
@@ -41,7 +41,7 @@ func (stats *FieldingStats) recordFielder(pos int, player game.PlayerID) {
 
 func (stats *FieldingStats) recordError(state *game.State, e game.FieldingError) {
 	f := stats.FieldingByPosition[e.Fielder-1]
-	player := state.Defense[e.Fielder-1]
+	player := state.GetErrorPlayer(e)
 	if player != "" {
 		stats.ErrorsByPlayer[player]++
 	}
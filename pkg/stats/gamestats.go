@@ -12,9 +12,10 @@ type GameStats struct {
 	TeamStats map[string]*TeamStats
 	RE        RunExpectancy
 
-	red   *REData
-	alt   *AltData
-	teams map[string]*game.Team
+	red     *REData
+	alt     *AltData
+	weights *Weights
+	teams   map[string]*game.Team
 }
 
 func NewGameStats(re RunExpectancy) *GameStats {
@@ -30,6 +31,12 @@ func NewGameStats(re RunExpectancy) *GameStats {
 func (gs *GameStats) Read(g *game.Game) error {
 	gs.teams[g.Home.Name] = g.Home
 	gs.teams[g.Visitor.Name] = g.Visitor
+	if gs.RE != nil {
+		if gs.weights == nil {
+			gs.weights = NewWeights(gs.RE)
+		}
+		gs.weights.Read(g)
+	}
 	states := g.GetStates()
 	for _, state := range states {
 		var battingTeam, fieldingTeam *game.Team
@@ -44,10 +51,12 @@ func (gs *GameStats) Read(g *game.Game) error {
 		fieldingTeamStats := gs.GetStats(fieldingTeam)
 		reChange := gs.red.Record(g.ID, state)
 		if alt := g.GetAlternativeState(state); alt != nil {
-			gs.alt.Record(g.ID, alt)
+			gs.alt.Record(g, alt)
 		}
 		battingTeamStats.RecordBatting(g, state, reChange)
+		battingTeamStats.RecordBaserunning(g, state, reChange)
 		fieldingTeamStats.RecordFielding(g, state)
+		fieldingTeamStats.RecordCatching(g, state)
 	}
 	return nil
 }
@@ -76,12 +85,73 @@ func (gs *GameStats) GetPitchingData() *dataframe.Data {
 	})
 }
 
+// appendTeamData returns the data of every team.
+func (gs *GameStats) appendTeamData(get func(*TeamStats) *dataframe.Data) *dataframe.Data {
+	var dat *dataframe.Data
+	for _, stats := range gs.TeamStats {
+		// the columns of an empty team have no type
+		if dat == nil || dat.RowCount() == 0 {
+			dat = get(stats)
+		} else {
+			dat.Append(get(stats))
+		}
+	}
+	return dat
+}
+
 func comparePlayers(idx *dataframe.Index, r1, r2 int) bool {
 	n1 := fmt.Sprintf("%v/%v", idx.GetValue(r1, "Team"), idx.GetValue(r1, "Name"))
 	n2 := fmt.Sprintf("%v/%v", idx.GetValue(r2, "Team"), idx.GetValue(r2, "Name"))
 	return strings.Compare(n1, n2) < 0
 }
 
+func (gs *GameStats) GetBaserunningData() *dataframe.Data {
+	dat := gs.appendTeamData((*TeamStats).GetBaserunningData)
+	idx := dat.GetIndex()
+	return dat.RSort(func(r1, r2 int) bool {
+		return comparePlayers(idx, r1, r2)
+	})
+}
+
+func (gs *GameStats) GetCatchingData() *dataframe.Data {
+	dat := gs.appendTeamData((*TeamStats).GetCatchingData)
+	idx := dat.GetIndex()
+	return dat.RSort(func(r1, r2 int) bool {
+		return comparePlayers(idx, r1, r2)
+	})
+}
+
+func (gs *GameStats) GetBatteryData() *dataframe.Data {
+	dat := gs.appendTeamData((*TeamStats).GetBatteryData)
+	return dat.RSort(dataframe.Less(
+		dataframe.CompareString(dat.GetColumn("Team")),
+		dataframe.CompareString(dat.GetColumn("Pitcher")),
+		dataframe.CompareString(dat.GetColumn("Catcher")),
+	))
+}
+
+func (gs *GameStats) GetTeamBaserunningData() *dataframe.Data {
+	dat := gs.GetBaserunningData()
+	var aggs []dataframe.Aggregation
+	for _, col := range dat.Columns {
+		switch col.Name {
+		case "PlayerID", "Name", "Team", "Number", "Games", "Inactive", "XBTPct":
+			continue
+		}
+		aggs = append(aggs, dataframe.ASum(col.Name, col).WithFormat(col.Format))
+	}
+	res := dat.GroupBy("Team").Aggregate(aggs...)
+	res.Name = "BR"
+	res.Add(dataframe.DeriveFloats("XBTPct", func(idx *dataframe.Index, i int) float64 {
+		opp := idx.GetInt(i, "XBTOpp")
+		if opp == 0 {
+			return 0
+		}
+		return float64(idx.GetInt(i, "XBT")) / float64(opp)
+	}).WithFormat("%6.3f"))
+	return res.RSort(dataframe.Less(dataframe.CompareString(res.GetColumn("Team"))))
+}
+
 func (gs *GameStats) GetBattingData() *dataframe.Data {
 	return gs.getBattingData(false)
 }
@@ -94,6 +164,10 @@ func (gs *GameStats) GetRE24Data() *dataframe.Data {
 	return gs.red.GetData()
 }
 
+func (gs *GameStats) GetWeights() *Weights {
+	return gs.weights
+}
+
 func (gs *GameStats) GetAltData() *dataframe.Data {
 	return gs.alt.GetData()
 }
@@ -105,6 +179,11 @@ func (gs *GameStats) GetPerPlayerAltData() *dataframe.Data {
 func (gs *GameStats) getBattingData(includeInactiveBatters bool) *dataframe.Data {
 	var dat *dataframe.Data
 	for _, stats := range gs.TeamStats {
+		if gs.weights != nil {
+			for _, batting := range stats.Batting {
+				gs.weights.Apply(batting)
+			}
+		}
 		if dat == nil {
 			dat = stats.GetBattingData()
 		} else {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,3 +42,34 @@ func TestAlt(t *testing.T) {
 	fmt.Println(gs.GetAltData())
 	// t.Fail()
 }
+
+func TestIntentionalWalk(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 10
+1 1 . IW
+2 2 BBBB W B-1 1-2
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	gs := NewGameStats(nil)
+	assert.NoError(gs.Read(g))
+	// an intentional walk is a walk for the batter but not a pitched walk
+	assert.Equal(1, gs.TeamStats["a"].Batting["1"].Walks)
+	assert.Equal(1, gs.TeamStats["a"].Batting["1"].IntentionalWalks)
+	p := gs.TeamStats["b"].Pitching["10"]
+	assert.Equal(1, p.Walks)
+	assert.Equal(1, p.IntentionalWalks)
+	assert.NotNil(gs.GetPitchingData().GetIndex().GetColumn("IntentionalWalks"))
+	assert.Nil(gs.GetPitchingData().GetIndex().GetColumn("WhiffIntentionalWalks"))
+}
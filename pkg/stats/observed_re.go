@@ -2,7 +2,6 @@ package stats
 
 import (
 	"fmt"
-	"io"
 	"sort"
 	"strconv"
 	"strings"
@@ -69,7 +68,7 @@ func (re *ObservedRunExpectancy) Read(g *game.Game) error {
 			}
 			continue
 		}
-		index := re.getIndex(state.Outs, GetOccupiedBases(state))
+		index := getState24Index(state.Outs, GetOccupiedBases(state))
 		if re.inProgress[index] == nil {
 			re.inProgress[index] = &stateObservation{
 				count: 1,
@@ -80,7 +79,7 @@ func (re *ObservedRunExpectancy) Read(g *game.Game) error {
 	return nil
 }
 
-func (re *ObservedRunExpectancy) getIndex(outs int, runrs OccupiedBases) int {
+func getState24Index(outs int, runrs OccupiedBases) int {
 	if outs == 3 {
 		return 0
 	}
@@ -101,7 +100,7 @@ func (re *ObservedRunExpectancy) GetExpectedRuns(outs int, runrs OccupiedBases)
 	if re.totals == nil {
 		return 0
 	}
-	state24 := re.totals[re.getIndex(outs, runrs)]
+	state24 := re.totals[getState24Index(outs, runrs)]
 	return state24.getExpectedRuns()
 }
 
@@ -109,7 +108,7 @@ func (re *ObservedRunExpectancy) GetExpectedRunsCount(outs int, runrs OccupiedBa
 	if re.totals == nil {
 		return 0
 	}
-	state24 := re.totals[re.getIndex(outs, runrs)]
+	state24 := re.totals[getState24Index(outs, runrs)]
 	return state24.count
 }
 
@@ -120,19 +119,6 @@ func (state24 *stateObservation) getExpectedRuns() (runs float64) {
 	return
 }
 
-func (re *ObservedRunExpectancy) WriteYAML(w io.Writer) error {
-	for i, runrs := range OccupedBasesValues {
-		_, err := fmt.Fprintf(w, "\"%s\": [ %.3f, %.3f, %.3f ]\n", runrs,
-			re.totals[i].getExpectedRuns(),
-			re.totals[i+8].getExpectedRuns(),
-			re.totals[i+16].getExpectedRuns())
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 func (re *ObservedRunExpectancy) GetRunData() *dataframe.Data {
 	return re.runData.Select(
 		deriveState24(),
@@ -14,6 +14,7 @@ type Pitching struct {
 	StrikeOuts, StrikeOutsLooking      int
 	Outs, GroundOuts, FlyOuts          int
 	WP, HP                             int
+	IntentionalWalks, IllegalPitches   int
 	BattersFaced                       int
 	StolenBases                        int
 	Whiff                              int
@@ -47,6 +48,9 @@ func (p *Pitching) Record(state *game.State) {
 	if state.Play.Is(game.WildPitch, game.WalkWildPitch, game.StrikeOutWildPitch) {
 		p.WP++
 	}
+	if state.Play.Type == game.IllegalPitch {
+		p.IllegalPitches++
+	}
 	if state.Play.Is(game.StolenBase, game.StrikeOutStolenBase) || len(state.StolenBases) > 0 {
 		p.StolenBases++
 	}
@@ -81,6 +85,9 @@ func (p *Pitching) Record(state *game.State) {
 		case game.WalkWildPitch:
 			p.Walks++
 			p.WP++
+		case game.IntentionalWalk:
+			// not a pitched walk
+			p.IntentionalWalks++
 		case game.HitByPitch:
 			p.HP++
 		case game.Double:
@@ -94,6 +101,8 @@ func (p *Pitching) Record(state *game.State) {
 		case game.GroundOut:
 			p.GroundOuts++
 		case game.FlyOut:
+			fallthrough
+		case game.InfieldFly:
 			p.FlyOuts++
 		}
 		if state.IsStrikeOut() {
@@ -0,0 +1,168 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+// REComparison compares run expectancy matrices state by state, and by
+// their effect on the RE24 and alt play costs of the same games.  The
+// first matrix is the base the others are compared to.
+type REComparison struct {
+	Names    []string
+	Matrices []RunExpectancy
+
+	stats []*GameStats
+}
+
+func (c *REComparison) Add(name string, re RunExpectancy) {
+	c.Names = append(c.Names, name)
+	c.Matrices = append(c.Matrices, re)
+	c.stats = append(c.stats, NewGameStats(re))
+}
+
+func (c *REComparison) Read(g *game.Game) error {
+	for _, gs := range c.stats {
+		if err := gs.Read(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMatrixData returns the expected runs of each state in each matrix,
+// and the difference from and ratio to the first matrix.
+func (c *REComparison) GetMatrixData() *dataframe.Data {
+	state := dataframe.NewColumn("St24", "%5s", dataframe.EmptyStrings)
+	dat := &dataframe.Data{Name: "RE", Columns: []*dataframe.Column{state}}
+	var values, diffs, ratios []*dataframe.Column
+	for i, name := range c.Names {
+		values = append(values, dataframe.NewColumn(name, "%5.3f", dataframe.EmptyFloats))
+		dat.Columns = append(dat.Columns, values[i])
+	}
+	for _, name := range c.Names[1:] {
+		diff := dataframe.NewColumn(name+"-"+c.Names[0], "% 6.3f", dataframe.EmptyFloats)
+		ratio := dataframe.NewColumn(name+"/"+c.Names[0], "%5.2f", dataframe.EmptyFloats)
+		diffs = append(diffs, diff)
+		ratios = append(ratios, ratio)
+		dat.Columns = append(dat.Columns, diff, ratio)
+	}
+	for outs := 0; outs < 3; outs++ {
+		for _, runrs := range OccupedBasesValues {
+			state.AppendString(fmt.Sprintf("%s/%d", runrs, outs))
+			base := c.Matrices[0].GetExpectedRuns(outs, runrs)
+			for i, re := range c.Matrices {
+				value := re.GetExpectedRuns(outs, runrs)
+				values[i].AppendFloat(value)
+				if i == 0 {
+					continue
+				}
+				diffs[i-1].AppendFloat(value - base)
+				if base != 0 {
+					ratios[i-1].AppendFloat(value / base)
+				} else {
+					ratios[i-1].AppendFloat(0)
+				}
+			}
+		}
+	}
+	return dat
+}
+
+type playerValues struct {
+	team, name string
+	values     []float64
+}
+
+// comparePlayerValues joins the value of each player under each matrix
+// and returns the players ordered by their value under the first matrix.
+func (c *REComparison) comparePlayerValues(name string, format string,
+	get func(gs *GameStats, add func(team, name string, value float64))) *dataframe.Data {
+	var (
+		keys    []string
+		players = map[string]*playerValues{}
+	)
+	for i, gs := range c.stats {
+		get(gs, func(team, name string, value float64) {
+			key := team + "/" + name
+			p := players[key]
+			if p == nil {
+				p = &playerValues{team: team, name: name, values: make([]float64, len(c.stats))}
+				players[key] = p
+				keys = append(keys, key)
+			}
+			p.values[i] += value
+		})
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return players[keys[i]].values[0] > players[keys[j]].values[0]
+	})
+	var (
+		nameCol = dataframe.NewColumn("Name", "%-12s", dataframe.EmptyStrings)
+		teamCol = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		values  []*dataframe.Column
+		ranks   []*dataframe.Column
+	)
+	dat := &dataframe.Data{Name: name, Columns: []*dataframe.Column{nameCol, teamCol}}
+	for _, n := range c.Names {
+		value := dataframe.NewColumn(n, format, dataframe.EmptyFloats)
+		value.Summary = dataframe.Sum
+		rank := dataframe.NewColumn(n+"Rk", "%3d", dataframe.EmptyInts)
+		values = append(values, value)
+		ranks = append(ranks, rank)
+		dat.Columns = append(dat.Columns, value, rank)
+	}
+	for _, key := range keys {
+		p := players[key]
+		nameCol.AppendString(p.name)
+		teamCol.AppendString(p.team)
+		for i, value := range p.values {
+			values[i].AppendFloat(value)
+			rank := 1
+			for _, other := range players {
+				if other.values[i] > value {
+					rank++
+				}
+			}
+			ranks[i].AppendInt(rank)
+		}
+	}
+	return dat
+}
+
+// GetRE24Data returns the batting RE24 of each batter under each matrix,
+// for the teams whose name starts with team (all teams if empty).
+func (c *REComparison) GetRE24Data(team string) *dataframe.Data {
+	return c.comparePlayerValues("RE24", "% 6.2f", func(gs *GameStats, add func(string, string, float64)) {
+		dat := gs.GetBattingData()
+		idx := dat.GetIndex()
+		for row := 0; row < dat.RowCount(); row++ {
+			t := idx.GetString(row, "Team")
+			if strings.HasPrefix(strings.ToLower(t), strings.ToLower(team)) {
+				add(t, idx.GetString(row, "Name"), idx.GetFloat(row, "RE24"))
+			}
+		}
+	})
+}
+
+// GetAltData returns the alt play cost credited to each player under each
+// matrix.
+func (c *REComparison) GetAltData() *dataframe.Data {
+	return c.comparePlayerValues("Alt Cost", "% 6.2f", func(gs *GameStats, add func(string, string, float64)) {
+		dat := gs.GetPerPlayerAltData()
+		idx := dat.GetIndex()
+		for row := 0; row < dat.RowCount(); row++ {
+			id := game.PlayerID(idx.GetString(row, "Player"))
+			team := idx.GetString(row, "Team")
+			name := string(id)
+			if t := gs.teams[team]; t != nil && t.Players[id] != nil {
+				name = t.Players[id].NameOrNumber()
+			}
+			add(team, name, idx.GetFloat(row, "Cost"))
+		}
+	})
+}
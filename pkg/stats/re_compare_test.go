@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestREComparison(t *testing.T) {
+	assert := assert.New(t)
+	c := &REComparison{}
+	for _, name := range []string{"d1_softball_re_2022", "tweaked_re"} {
+		re, err := ReadREMatrix("../../data/" + name + ".csv")
+		if !assert.NoError(err) {
+			return
+		}
+		c.Add(name, re)
+	}
+	dat := c.GetMatrixData()
+	assert.Equal(24, dat.RowCount())
+	idx := dat.GetIndex()
+	assert.InDelta(1.003-0.645, idx.GetFloat(0, "tweaked_re-d1_softball_re_2022"), 0.0001)
+	games, err := game.ReadGameFiles([]string{"../gamefile/testdata/test.gm"})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NoError(c.Read(games[0]))
+	re24 := c.GetRE24Data("pride")
+	assert.Greater(re24.RowCount(), 0)
+	assert.Equal(1, re24.GetIndex().GetInt(0, "d1_softball_re_2022Rk"))
+	assert.NotNil(c.GetAltData())
+}
+
+func TestREComparisonAltTeams(t *testing.T) {
+	assert := assert.New(t)
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	c := &REComparison{}
+	c.Add("tweaked_re", re)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+defense 6 at 6
+1 1 X E6/G6 B-1
+  alt 63/G6
+homeplays
+defense 6 at 6
+1 1 X E6/G6 B-1
+  alt 63/G6
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NoError(c.Read(g))
+	// the shortstops of both teams are 6
+	dat := c.GetAltData()
+	if assert.Equal(2, dat.RowCount()) {
+		idx := dat.GetIndex()
+		assert.ElementsMatch([]string{"a", "b"}, []string{idx.GetString(0, "Team"), idx.GetString(1, "Team")})
+	}
+}
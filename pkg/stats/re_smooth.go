@@ -0,0 +1,219 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+)
+
+// SmoothedRunExpectancy is an observed run expectancy that is shrunk
+// toward a prior matrix and then smoothed so that more outs never raise
+// the expected runs and more runners never lower them.  Each state also
+// has a bootstrap confidence interval.
+type SmoothedRunExpectancy struct {
+	// Prior is the matrix to shrink toward, or nil for none.
+	Prior RunExpectancy
+	// PriorWeight is the number of observations the prior is worth.
+	PriorWeight float64
+	// Samples is the number of bootstrap samples.
+	Samples int
+	// Confidence is the width of the confidence interval, e.g. 0.9.
+	Confidence float64
+
+	samples [24][]int
+	values  [24]float64
+	low     [24]float64
+	high    [24]float64
+}
+
+var _ RunExpectancy = (*SmoothedRunExpectancy)(nil)
+var _ RunExpectancyCounts = (*SmoothedRunExpectancy)(nil)
+
+func NewSmoothedRunExpectancy(prior RunExpectancy) *SmoothedRunExpectancy {
+	return &SmoothedRunExpectancy{
+		Prior:       prior,
+		PriorWeight: 20,
+		Samples:     1000,
+		Confidence:  0.9,
+	}
+}
+
+// Smooth computes the smoothed matrix and confidence intervals from the
+// runs scored after each base/out state in the observed games.
+func (sre *SmoothedRunExpectancy) Smooth(observed *ObservedRunExpectancy) error {
+	sre.samples = [24][]int{}
+	if observed.runData != nil {
+		index, runs := observed.runData.Columns[0], observed.runData.Columns[1]
+		for i := 0; i < index.Len(); i++ {
+			sre.samples[index.GetInt(i)] = append(sre.samples[index.GetInt(i)], runs.GetInt(i))
+		}
+	}
+	var err error
+	sre.values, err = sre.estimate(sre.samples)
+	if err != nil {
+		return err
+	}
+	if sre.Samples <= 0 {
+		sre.low, sre.high = sre.values, sre.values
+		return nil
+	}
+	// a fixed seed keeps the intervals the same from run to run
+	rnd := rand.New(rand.NewSource(1))
+	var boot [24][]float64
+	for b := 0; b < sre.Samples; b++ {
+		var resampled [24][]int
+		for i, obs := range sre.samples {
+			resampled[i] = make([]int, len(obs))
+			for j := range obs {
+				resampled[i][j] = obs[rnd.Intn(len(obs))]
+			}
+		}
+		values, err := sre.estimate(resampled)
+		if err != nil {
+			return err
+		}
+		for i := range values {
+			boot[i] = append(boot[i], values[i])
+		}
+	}
+	alpha := (1 - sre.Confidence) / 2
+	for i := range boot {
+		sort.Float64s(boot[i])
+		sre.low[i] = percentile(boot[i], alpha)
+		sre.high[i] = percentile(boot[i], 1-alpha)
+	}
+	return nil
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	i := int(math.Round(p * float64(len(sorted)-1)))
+	return sorted[i]
+}
+
+// estimate shrinks the mean runs of each state toward the prior and then
+// smooths the result.
+func (sre *SmoothedRunExpectancy) estimate(samples [24][]int) ([24]float64, error) {
+	var values, weights [24]float64
+	for i, obs := range samples {
+		total := 0
+		for _, runs := range obs {
+			total += runs
+		}
+		n := float64(len(obs))
+		values[i] = float64(total)
+		weights[i] = n
+		if sre.Prior != nil {
+			values[i] += sre.PriorWeight * sre.Prior.GetExpectedRuns(i/8, OccupedBasesValues[i%8])
+			weights[i] += sre.PriorWeight
+		}
+		if weights[i] > 0 {
+			values[i] /= weights[i]
+		}
+		// states without any data are filled in by their neighbors
+		weights[i] = math.Max(weights[i], 0.01)
+	}
+	err := smoothRunExpectancy(&values, weights)
+	return values, err
+}
+
+// smoothRunExpectancy adjusts values so that a state never has more
+// expected runs than a state with the same runners and fewer outs, or
+// than a state with the same outs and more runners.  The states are
+// pooled into blocks that share their weighted mean, and the blocks of
+// the pair of states that most violates the order are merged until no
+// violations remain.
+func smoothRunExpectancy(values *[24]float64, weights [24]float64) error {
+	// lessOrEqual reports whether state i must not have more expected
+	// runs than state j
+	lessOrEqual := func(i, j int) bool {
+		if i == j {
+			return false
+		}
+		outsI, outsJ := i/8, j/8
+		basesI, basesJ := i%8, j%8
+		return outsI >= outsJ && basesI&basesJ == basesI
+	}
+	var (
+		block       [24]int
+		blockValues = *values
+		blockWeight = weights
+	)
+	for i := range block {
+		block[i] = i
+	}
+	// each merge leaves one less block, so there are at most 23
+	for merges := 0; merges < 24; merges++ {
+		worst, a, b := 1e-9, -1, -1
+		for i := 0; i < 24; i++ {
+			for j := 0; j < 24; j++ {
+				bi, bj := block[i], block[j]
+				if bi != bj && lessOrEqual(i, j) && blockValues[bi]-blockValues[bj] > worst {
+					worst, a, b = blockValues[bi]-blockValues[bj], bi, bj
+				}
+			}
+		}
+		if a < 0 {
+			for i := range values {
+				values[i] = blockValues[block[i]]
+			}
+			return nil
+		}
+		weight := blockWeight[a] + blockWeight[b]
+		blockValues[a] = (blockValues[a]*blockWeight[a] + blockValues[b]*blockWeight[b]) / weight
+		blockWeight[a] = weight
+		for i := range block {
+			if block[i] == b {
+				block[i] = a
+			}
+		}
+	}
+	return fmt.Errorf("the run expectancy could not be smoothed")
+}
+
+func (sre *SmoothedRunExpectancy) GetExpectedRuns(outs int, runrs OccupiedBases) float64 {
+	return sre.values[getState24Index(outs, runrs)]
+}
+
+func (sre *SmoothedRunExpectancy) GetExpectedRunsCount(outs int, runrs OccupiedBases) int {
+	return len(sre.samples[getState24Index(outs, runrs)])
+}
+
+// GetConfidenceInterval returns the low and high bounds of the expected
+// runs of a state.
+func (sre *SmoothedRunExpectancy) GetConfidenceInterval(outs int, runrs OccupiedBases) (float64, float64) {
+	i := getState24Index(outs, runrs)
+	return sre.low[i], sre.high[i]
+}
+
+// GetData returns the smoothed matrix with the confidence interval and
+// the number of observations of each state.
+func (sre *SmoothedRunExpectancy) GetData() *dataframe.Data {
+	runners := dataframe.NewColumn("Runr", "%4s", dataframe.EmptyStrings)
+	dat := &dataframe.Data{Columns: []*dataframe.Column{runners}}
+	var values, lows, highs, counts [3]*dataframe.Column
+	for outs := 0; outs < 3; outs++ {
+		name := []string{"0Out", "1Out", "2Out"}[outs]
+		values[outs] = dataframe.NewColumn(name, "%5.3f", dataframe.EmptyFloats)
+		lows[outs] = dataframe.NewColumn(name+"Lo", "%6.3f", dataframe.EmptyFloats)
+		highs[outs] = dataframe.NewColumn(name+"Hi", "%6.3f", dataframe.EmptyFloats)
+		counts[outs] = dataframe.NewColumn(name+"Count", "%9d", dataframe.EmptyInts)
+		dat.Columns = append(dat.Columns, values[outs], lows[outs], highs[outs])
+	}
+	for outs := 0; outs < 3; outs++ {
+		dat.Columns = append(dat.Columns, counts[outs])
+	}
+	for _, runrs := range OccupedBasesValues {
+		runners.AppendString(string(runrs))
+		for outs := 0; outs < 3; outs++ {
+			low, high := sre.GetConfidenceInterval(outs, runrs)
+			values[outs].AppendFloat(sre.GetExpectedRuns(outs, runrs))
+			lows[outs].AppendFloat(low)
+			highs[outs].AppendFloat(high)
+			counts[outs].AppendInt(sre.GetExpectedRunsCount(outs, runrs))
+		}
+	}
+	return dat
+}
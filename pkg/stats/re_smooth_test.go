@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmoothedRunExpectancy(t *testing.T) {
+	assert := assert.New(t)
+	observed := &ObservedRunExpectancy{}
+	files, err := filepath.Glob("../../data/2021/2021*.yaml")
+	if !assert.NoError(err) {
+		return
+	}
+	for _, f := range files {
+		g, err := game.ReadGameFile(f)
+		if assert.NoError(err) {
+			assert.NoError(observed.Read(g))
+		}
+	}
+	prior, err := ReadREMatrix("../../data/d1_softball_re_2022.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	sre := NewSmoothedRunExpectancy(prior)
+	sre.Samples = 100
+	assert.NoError(sre.Smooth(observed))
+	for _, runrs := range OccupedBasesValues {
+		for outs := 0; outs < 3; outs++ {
+			re := sre.GetExpectedRuns(outs, runrs)
+			assert.Greater(re, 0.0)
+			if outs > 0 {
+				assert.LessOrEqual(re, sre.GetExpectedRuns(outs-1, runrs)+1e-6)
+			}
+			low, high := sre.GetConfidenceInterval(outs, runrs)
+			assert.LessOrEqual(low, high)
+		}
+	}
+	assert.LessOrEqual(sre.GetExpectedRuns(0, RunnerOnFirst), sre.GetExpectedRuns(0, RunnersOnFirstAndThird)+1e-6)
+	assert.Equal(8, sre.GetData().RowCount())
+}
+
+func TestSmoothRunExpectancy(t *testing.T) {
+	assert := assert.New(t)
+	var values, weights [24]float64
+	for i := range values {
+		values[i] = float64(i%8) + float64(3-i/8)/10
+		weights[i] = 1
+	}
+	monotone := values
+	assert.NoError(smoothRunExpectancy(&values, weights))
+	assert.Equal(monotone, values)
+	// 1 out with the bases empty is more than 0 outs
+	values[8] = 0.35
+	weights[8] = 3
+	assert.NoError(smoothRunExpectancy(&values, weights))
+	assert.InDelta(0.3375, values[0], 0.001)
+	assert.InDelta(0.3375, values[8], 0.001)
+	assertMonotone(t, values)
+	// a state pooled with one neighbor is pooled with the next using the
+	// weight of both
+	values[0], values[8], values[16] = 0.1, 0.3, 0.5
+	weights[0], weights[8], weights[16] = 1, 1, 4
+	assert.NoError(smoothRunExpectancy(&values, weights))
+	for _, i := range []int{0, 8, 16} {
+		assert.InDelta(0.4, values[i], 0.001)
+	}
+	assertMonotone(t, values)
+}
+
+func assertMonotone(t *testing.T, values [24]float64) {
+	for i := 0; i < 24; i++ {
+		for j := 0; j < 24; j++ {
+			if i/8 >= j/8 && i%8&(j%8) == i%8 {
+				assert.LessOrEqual(t, values[i], values[j]+1e-6)
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+type RunningCountSituations struct {
+	Us    string
+	NotUs string
+
+	sits []*RunningCount
+}
+
+type RunningCount struct {
+	Count          string
+	Pitches        int
+	StolenBases    int
+	CaughtStealing int
+	PickedOff      int
+	WildPitches    int
+	PassedBalls    int
+}
+
+func NewRunningByCount() *RunningCountSituations {
+	sits := make([]*RunningCount, 4*3)
+	for balls := 0; balls <= 3; balls++ {
+		for strikes := 0; strikes <= 2; strikes++ {
+			sits[strikes*4+balls] = &RunningCount{
+				Count: fmt.Sprintf("%d-%d", balls, strikes),
+			}
+		}
+	}
+	return &RunningCountSituations{
+		sits: sits,
+	}
+}
+
+func (rc *RunningCountSituations) Read(g *game.Game) {
+	for _, state := range getBattingStates(g, rc.Us, rc.NotUs) {
+		rc.record(state)
+	}
+}
+
+func (rc *RunningCountSituations) getSituation(known bool, balls, strikes int) *RunningCount {
+	if !known || balls > 3 || strikes > 2 {
+		return nil
+	}
+	return rc.sits[strikes*4+balls]
+}
+
+func (rc *RunningCountSituations) record(state *game.State) {
+	last := state.LastState
+	if last == nil || last.Runners == [3]game.PlayerID{} {
+		return
+	}
+	// count the pitches thrown with runners on for this line
+	start := 0
+	if !(last.Complete || last.Incomplete) {
+		start = len(last.Pitches)
+	}
+	for i := start; i < len(state.Pitches); i++ {
+		if state.Pitches[i] == '.' {
+			continue
+		}
+		known, _, balls, strikes := state.Pitches[0:i].Count()
+		if sit := rc.getSituation(known, balls, strikes); sit != nil {
+			sit.Pitches++
+		}
+	}
+	known, _, balls, strikes := state.EventCount()
+	sit := rc.getSituation(known, balls, strikes)
+	if sit == nil {
+		return
+	}
+	sit.StolenBases += len(state.StolenBases)
+	if state.Play.Is(game.CaughtStealing, game.StrikeOutCaughtStealing) && !state.NotOutOnPlay {
+		sit.CaughtStealing++
+	}
+	if state.Play.Is(game.PickedOff, game.WalkPickedOff, game.StrikeOutPickedOff) && !state.NotOutOnPlay {
+		sit.PickedOff++
+	}
+	if state.Play.Is(game.WildPitch, game.WalkWildPitch, game.StrikeOutWildPitch) {
+		sit.WildPitches++
+	}
+	if state.Play.Is(game.PassedBall, game.WalkPassedBall, game.StrikeOutPassedBall) {
+		sit.PassedBalls++
+	}
+	for _, adv := range state.Advances {
+		if adv.WildPitch {
+			sit.WildPitches++
+		}
+		if adv.PassedBall {
+			sit.PassedBalls++
+		}
+	}
+}
+
+func (rc *RunningCountSituations) GetData() *dataframe.Data {
+	dat := &dataframe.Data{}
+	var idx *dataframe.Index
+	for _, sit := range rc.sits {
+		idx = dat.AppendStruct(idx, sit)
+	}
+	return dat.Select(
+		dataframe.Col("Count").WithFormat("%5s"),
+		dataframe.Col("Pitches").WithFormat("%7d"),
+		dataframe.Rename("StolenBases", "SB").WithFormat("%3d"),
+		dataframe.Rename("CaughtStealing", "CS").WithFormat("%3d"),
+		dataframe.DeriveFloats("SB%", stolenBasePct).WithFormat("%6.3f"),
+		dataframe.Rename("PickedOff", "PO").WithFormat("%3d"),
+		dataframe.Rename("WildPitches", "WP").WithFormat("%3d"),
+		dataframe.Rename("PassedBalls", "PB").WithFormat("%3d"),
+		dataframe.DeriveFloats("WP%", wildPitchPct).WithFormat("%6.3f"),
+	)
+}
+
+func stolenBasePct(idx *dataframe.Index, i int) float64 {
+	sb := idx.GetInt(i, "StolenBases")
+	cs := idx.GetInt(i, "CaughtStealing")
+	if sb+cs == 0 {
+		return 0
+	}
+	return float64(sb) / float64(sb+cs)
+}
+
+func wildPitchPct(idx *dataframe.Index, i int) float64 {
+	pitches := idx.GetInt(i, "Pitches")
+	if pitches == 0 {
+		return 0
+	}
+	return float64(idx.GetInt(i, "WildPitches")) / float64(pitches)
+}
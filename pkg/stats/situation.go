@@ -0,0 +1,41 @@
+package stats
+
+import (
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+// Situation is a play in a game with the batter's and pitcher's lines
+// for the game through the play, and the change in run expectancy on the
+// play.
+type Situation struct {
+	State    *game.State
+	Batting  *Batting
+	Pitching *Pitching
+	// REBefore, REAfter and REChange are zero without a run expectancy
+	REBefore, REAfter, REChange float64
+}
+
+func NewSituation(g *game.Game, state *game.State, re RunExpectancy) *Situation {
+	batting := NewStats(state.BattingTeam, re)
+	fielding := NewStats(state.FieldingTeam, re)
+	for _, s := range g.GetStates() {
+		if s.Half == state.Half {
+			batting.RecordBatting(g, s, 0)
+			fielding.RecordFielding(g, s)
+		}
+		if s == state {
+			break
+		}
+	}
+	sit := &Situation{
+		State:    state,
+		Batting:  batting.GetBatting(state.Pos, state.Batter),
+		Pitching: fielding.GetPitching(state.Pitcher),
+	}
+	sit.Batting.Update()
+	sit.Pitching.Update()
+	if re != nil {
+		sit.REBefore, sit.REAfter, _, sit.REChange = GetExpectedRunsChange(re, state)
+	}
+	return sit
+}
@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSituation(t *testing.T) {
+	assert := assert.New(t)
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 9
+1 1 BX S8/G8
+2 2 BBBB W B-1 1-2
+3 1 CBX D7/L7 2-H 1-H
+homeplays
+pitching 8
+1 1 CSS K
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	states := g.GetVisitorStates()
+	sit := NewSituation(g, states[2], re)
+	assert.Equal(2, sit.Batting.Hits)
+	assert.Equal(2, sit.Batting.AB)
+	assert.Equal(1, sit.Batting.Doubles)
+	assert.Equal(9, sit.Pitching.Pitches)
+	assert.Equal(1, sit.Pitching.Walks)
+	assert.Equal(re.GetExpectedRuns(0, RunnerOnFirstAndSecond), sit.REBefore)
+	assert.Equal(re.GetExpectedRuns(0, RunnerOnSecond), sit.REAfter)
+	assert.InDelta(sit.REAfter-sit.REBefore+2, sit.REChange, 1e-9)
+	// only the plays up to the situation are counted
+	sit = NewSituation(g, states[0], nil)
+	assert.Equal(1, sit.Batting.Hits)
+	assert.Equal(2, sit.Pitching.Pitches)
+	assert.Zero(sit.REChange)
+}
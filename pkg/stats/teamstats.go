@@ -8,13 +8,21 @@ import (
 )
 
 type TeamStats struct {
-	Batting  map[game.PlayerID]*Batting
-	Pitching map[game.PlayerID]*Pitching
+	Batting     map[game.PlayerID]*Batting
+	Pitching    map[game.PlayerID]*Pitching
+	Baserunning map[game.PlayerID]*Baserunning
+	Catching    map[game.PlayerID]*Catching
+	Batteries   map[batteryKey]*Battery
 	*FieldingStats
-	LOB      int
-	Batters  []game.PlayerID
-	Pitchers []game.PlayerID
-	Team     *game.Team
+	LOB         int
+	Batters     []game.PlayerID
+	Pitchers    []game.PlayerID
+	Baserunners []game.PlayerID
+	Catchers    []game.PlayerID
+	Team        *game.Team
+
+	re          RunExpectancy
+	batteryKeys []batteryKey
 }
 
 func NewStats(team *game.Team, re RunExpectancy) *TeamStats {
@@ -23,6 +31,10 @@ func NewStats(team *game.Team, re RunExpectancy) *TeamStats {
 		FieldingStats: newFieldingStats(),
 		Batting:       make(map[game.PlayerID]*Batting),
 		Pitching:      make(map[game.PlayerID]*Pitching),
+		Baserunning:   make(map[game.PlayerID]*Baserunning),
+		Catching:      make(map[game.PlayerID]*Catching),
+		Batteries:     make(map[batteryKey]*Battery),
+		re:            re,
 	}
 }
 
@@ -123,6 +135,8 @@ func (stats *TeamStats) RecordBatting(g *game.Game, state *game.State, reChange
 			runners = []game.PlayerID{state.CaughtStealingRunner}
 		case state.Play.Is(game.PickedOff, game.WalkPickedOff):
 			runners = []game.PlayerID{state.PickedOffRunner}
+		case state.Play.Is(game.AppealOut, game.RunnerInterference):
+			runners = []game.PlayerID{state.Play.PutOutRunner}
 		case state.Play.Type == game.StolenBase:
 			for _, adv := range state.Advances {
 				if adv.Steal {
@@ -209,4 +223,9 @@ func (stats *TeamStats) RecordFielding(g *game.Game, state *game.State) {
 			stats.recordError(state, adv.FieldingError)
 		}
 	}
+	for _, se := range state.ScorerErrors {
+		if se.Added {
+			stats.recordError(state, se.FieldingError)
+		}
+	}
 }
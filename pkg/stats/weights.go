@@ -0,0 +1,212 @@
+package stats
+
+import (
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+type WeightEvent string
+
+const (
+	WeightSingle  = WeightEvent("1B")
+	WeightDouble  = WeightEvent("2B")
+	WeightTriple  = WeightEvent("3B")
+	WeightHomeRun = WeightEvent("HR")
+	WeightWalk    = WeightEvent("BB")
+	WeightHBP     = WeightEvent("HBP")
+	WeightROE     = WeightEvent("ROE")
+	WeightK       = WeightEvent("K")
+	WeightOut     = WeightEvent("Out")
+)
+
+var WeightEvents = []WeightEvent{
+	WeightSingle, WeightDouble, WeightTriple, WeightHomeRun, WeightWalk,
+	WeightHBP, WeightROE, WeightK, WeightOut,
+}
+
+// Weights derives the linear weight of each batting event from the run
+// expectancy change of every plate appearance that ended in that event.
+type Weights struct {
+	RE RunExpectancy
+
+	counts map[WeightEvent]int
+	values map[WeightEvent]float64
+	league Batting
+	runs   int
+}
+
+func NewWeights(re RunExpectancy) *Weights {
+	return &Weights{
+		RE:     re,
+		counts: map[WeightEvent]int{},
+		values: map[WeightEvent]float64{},
+	}
+}
+
+func GetWeightEvent(state *game.State) (WeightEvent, bool) {
+	switch {
+	case !state.Complete:
+		return "", false
+	case state.Play.Is(game.Single):
+		return WeightSingle, true
+	case state.Play.Is(game.Double, game.GroundRuleDouble):
+		return WeightDouble, true
+	case state.Play.Is(game.Triple):
+		return WeightTriple, true
+	case state.Play.Is(game.HomeRun):
+		return WeightHomeRun, true
+	case state.Play.IsWalk():
+		return WeightWalk, true
+	case state.Play.Is(game.HitByPitch):
+		return WeightHBP, true
+	case state.Play.Is(game.ReachedOnError):
+		return WeightROE, true
+	case state.IsStrikeOut():
+		return WeightK, true
+	case state.Play.Is(game.GroundOut, game.FlyOut, game.InfieldFly, game.DoublePlay,
+		game.TriplePlay, game.FieldersChoice, game.BatterInterference):
+		return WeightOut, true
+	}
+	return "", false
+}
+
+func (w *Weights) Read(g *game.Game) {
+	for _, state := range g.GetStates() {
+		w.league.Record(state)
+		w.runs += len(state.ScoringRunners)
+		event, ok := GetWeightEvent(state)
+		if !ok {
+			continue
+		}
+		_, _, _, change := GetExpectedRunsChange(w.RE, state)
+		w.counts[event]++
+		w.values[event] += change
+	}
+}
+
+// GetRunValue returns the average change in run expectancy for event.
+func (w *Weights) GetRunValue(event WeightEvent) float64 {
+	if w.counts[event] == 0 {
+		return 0
+	}
+	return w.values[event] / float64(w.counts[event])
+}
+
+func (w *Weights) getOutRunValue() float64 {
+	n := w.counts[WeightK] + w.counts[WeightOut]
+	if n == 0 {
+		return 0
+	}
+	return (w.values[WeightK] + w.values[WeightOut]) / float64(n)
+}
+
+// GetLinearWeight returns the run value of event above the run value of
+// an out.
+func (w *Weights) GetLinearWeight(event WeightEvent) float64 {
+	return w.GetRunValue(event) - w.getOutRunValue()
+}
+
+// GetScale returns the factor that puts wOBA on the same scale as OBP.
+func (w *Weights) GetScale() float64 {
+	raw := w.getWOBA(&w.league, 1)
+	if raw == 0 {
+		return 0
+	}
+	return w.getOBP(&w.league) / raw
+}
+
+func (w *Weights) GetWOBAWeight(event WeightEvent) float64 {
+	return w.GetLinearWeight(event) * w.GetScale()
+}
+
+func (w *Weights) GetLeagueWOBA() float64 {
+	return w.GetWOBA(&w.league)
+}
+
+func (w *Weights) GetWOBA(b *Batting) float64 {
+	return w.getWOBA(b, w.GetScale())
+}
+
+func (w *Weights) getWOBA(b *Batting, scale float64) float64 {
+	walks := b.Walks - b.IntentionalWalks
+	denom := b.AB + walks + b.SacrificeFlys + b.HitByPitch
+	if denom == 0 {
+		return 0
+	}
+	num := float64(walks)*w.GetLinearWeight(WeightWalk) +
+		float64(b.HitByPitch)*w.GetLinearWeight(WeightHBP) +
+		float64(b.Singles)*w.GetLinearWeight(WeightSingle) +
+		float64(b.Doubles)*w.GetLinearWeight(WeightDouble) +
+		float64(b.Triples)*w.GetLinearWeight(WeightTriple) +
+		float64(b.HRs)*w.GetLinearWeight(WeightHomeRun) +
+		float64(b.ReachedOnError)*w.GetLinearWeight(WeightROE)
+	return scale * num / float64(denom)
+}
+
+func (w *Weights) getOBP(b *Batting) float64 {
+	denom := b.AB + b.Walks + b.HitByPitch + b.SacrificeFlys
+	if denom == 0 {
+		return 0
+	}
+	return float64(b.Hits+b.Walks+b.HitByPitch) / float64(denom)
+}
+
+// GetWRAA returns the runs a batter produced above an average batter.
+func (w *Weights) GetWRAA(b *Batting) float64 {
+	scale := w.GetScale()
+	if scale == 0 {
+		return 0
+	}
+	return (w.GetWOBA(b) - w.GetLeagueWOBA()) / scale * float64(b.PA)
+}
+
+// GetWRC returns the runs a batter created.
+func (w *Weights) GetWRC(b *Batting) float64 {
+	if w.league.PA == 0 {
+		return 0
+	}
+	runsPerPA := float64(w.runs) / float64(w.league.PA)
+	return w.GetWRAA(b) + runsPerPA*float64(b.PA)
+}
+
+// Apply sets the weighted batting stats of b.
+func (w *Weights) Apply(b *Batting) {
+	b.WOBA = w.GetWOBA(b)
+	b.WRAA = w.GetWRAA(b)
+	b.WRC = w.GetWRC(b)
+}
+
+func (w *Weights) GetData() *dataframe.Data {
+	var (
+		event  = dataframe.NewColumn("Event", "%-5s", dataframe.EmptyStrings)
+		count  = dataframe.NewColumn("N", "%5d", dataframe.EmptyInts)
+		value  = dataframe.NewColumn("RunValue", "% 8.3f", dataframe.EmptyFloats)
+		linear = dataframe.NewColumn("LWeight", "% 7.3f", dataframe.EmptyFloats)
+		woba   = dataframe.NewColumn("wOBA", "% 6.3f", dataframe.EmptyFloats)
+	)
+	for _, e := range WeightEvents {
+		event.AppendString(string(e))
+		count.AppendInt(w.counts[e])
+		value.AppendFloat(w.GetRunValue(e))
+		linear.AppendFloat(w.GetLinearWeight(e))
+		woba.AppendFloat(w.GetWOBAWeight(e))
+	}
+	return &dataframe.Data{
+		Name:    "Weights",
+		Columns: []*dataframe.Column{event, count, value, linear, woba},
+	}
+}
+
+// GetScaleData returns the wOBA scale and the league wOBA.
+func (w *Weights) GetScaleData() *dataframe.Data {
+	var (
+		scale  = dataframe.NewColumn("Scale", "%5.3f", dataframe.EmptyFloats)
+		league = dataframe.NewColumn("LeagueWOBA", "%10.3f", dataframe.EmptyFloats)
+	)
+	scale.AppendFloat(w.GetScale())
+	league.AppendFloat(w.GetLeagueWOBA())
+	return &dataframe.Data{
+		Name:    "Scale",
+		Columns: []*dataframe.Column{scale, league},
+	}
+}
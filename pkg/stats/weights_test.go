@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeights(t *testing.T) {
+	assert := assert.New(t)
+	re, err := ReadREMatrix("../../data/tweaked_re.csv")
+	if !assert.NoError(err) {
+		return
+	}
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+1 1 X S8/G6
+2 2 BBBB W 1-2
+3 3 X D8/F8 B-2 2-H 1-H
+4 4 SSS K
+5 5 X 63/G6
+6 6 X 8/F8
+`)
+	if !assert.NoError(err) {
+		return
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(err) {
+		return
+	}
+	w := NewWeights(re)
+	w.Read(g)
+	assert.Greater(w.GetRunValue(WeightDouble), w.GetRunValue(WeightSingle))
+	assert.Greater(w.GetLinearWeight(WeightSingle), w.GetLinearWeight(WeightWalk))
+	assert.Less(w.GetRunValue(WeightK), 0.0)
+	assert.Greater(w.GetScale(), 0.0)
+	b := &Batting{PA: 1, AB: 1, Hits: 1, Doubles: 1}
+	assert.Greater(w.GetWOBA(b), w.GetLeagueWOBA())
+	assert.Greater(w.GetWRAA(b), 0.0)
+	assert.Greater(w.GetWRC(b), w.GetWRAA(b))
+	assert.Equal(len(WeightEvents), w.GetData().RowCount())
+	assert.Equal(w.GetScale(), w.GetScaleData().Columns[0].GetFloat(0))
+}
@@ -0,0 +1,365 @@
+package tournament
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+)
+
+// RestRule requires Days days of rest after a day with at least Pitches
+// pitches.
+type RestRule struct {
+	Pitches, Days int
+}
+
+// RestRules are the pitch limits.  A zero limit is not checked.
+type RestRules struct {
+	MaxGame      int
+	MaxDay       int
+	MaxThreeDays int
+	Rest         []RestRule
+}
+
+// Outing is a pitcher's work in one game on one date.
+type Outing struct {
+	Team         string
+	PlayerID     game.PlayerID
+	Name         string
+	Tournament   string
+	GameID       string
+	Date         time.Time
+	Pitches      int
+	BattersFaced int
+	DayPitches   int
+	DaysPitches3 int
+	DaysRest     int
+	Flags        string
+}
+
+type Availability struct {
+	Team         string
+	Name         string
+	LastPitched  time.Time
+	DayPitches   int
+	DaysPitches3 int
+	RestRequired int
+	DaysRest     int
+	Available    bool
+	Remaining    int
+	Reason       string
+}
+
+type Workload struct {
+	Rules   RestRules
+	Outings []*Outing
+}
+
+type pitcherKey struct {
+	team    string
+	pitcher game.PlayerID
+}
+
+// ParseRestRules parses rules like "36:1,51:2,66:3".
+func ParseRestRules(s string) ([]RestRule, error) {
+	var rules []RestRule
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		pitches, days, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("rest rule %s is not pitches:days", part)
+		}
+		var (
+			rule RestRule
+			err  error
+		)
+		if rule.Pitches, err = strconv.Atoi(pitches); err != nil {
+			return nil, fmt.Errorf("rest rule %s - %w", part, err)
+		}
+		if rule.Days, err = strconv.Atoi(days); err != nil {
+			return nil, fmt.Errorf("rest rule %s - %w", part, err)
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Pitches < rules[j].Pitches
+	})
+	return rules, nil
+}
+
+// GetRequiredRest returns the days of rest required after pitching
+// pitches in a day.
+func (rules RestRules) GetRequiredRest(pitches int) int {
+	days := 0
+	for _, rule := range rules.Rest {
+		if pitches >= rule.Pitches {
+			days = rule.Days
+		}
+	}
+	return days
+}
+
+func NewWorkload(rules RestRules, games []*game.Game) *Workload {
+	w := &Workload{Rules: rules}
+	sorted := append([]*game.Game{}, games...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GetDate().Before(sorted[j].GetDate())
+	})
+	for _, g := range sorted {
+		w.read(g)
+	}
+	sort.SliceStable(w.Outings, func(i, j int) bool {
+		return w.Outings[i].Date.Before(w.Outings[j].Date)
+	})
+	w.check()
+	return w
+}
+
+func (w *Workload) read(g *game.Game) {
+	outings := map[string]*Outing{}
+	for _, state := range g.GetStates() {
+		if state.Pitcher == "" {
+			continue
+		}
+		team := g.Home
+		if !state.Top() {
+			team = g.Visitor
+		}
+		date := g.GetStateDate(state)
+		key := fmt.Sprintf("%s/%s/%s", team.Name, state.Pitcher, date)
+		outing := outings[key]
+		if outing == nil {
+			outing = &Outing{
+				Team:       team.Name,
+				PlayerID:   state.Pitcher,
+				Name:       team.GetPlayer(state.Pitcher).NameOrNumber(),
+				Tournament: g.GetTournament(),
+				GameID:     g.ID,
+				Date:       date,
+			}
+			outings[key] = outing
+			w.Outings = append(w.Outings, outing)
+		}
+		outing.Pitches += stats.GetPitchCount(state)
+		if state.LastState == nil || state.LastState.Batter != state.Batter || state.LastState.Pitcher != state.Pitcher {
+			outing.BattersFaced++
+		}
+	}
+}
+
+// check computes the daily and three day totals for each outing, and
+// flags the outings that break the rules.
+func (w *Workload) check() {
+	var previous = map[pitcherKey][]*Outing{}
+	for _, outing := range w.Outings {
+		key := pitcherKey{outing.Team, outing.PlayerID}
+		before := previous[key]
+		outing.DayPitches = outing.Pitches + w.pitchesSince(before, outing.Date, 0)
+		outing.DaysPitches3 = outing.Pitches + w.pitchesSince(before, outing.Date, 2)
+		outing.DaysRest = -1
+		var flags []string
+		if w.Rules.MaxGame > 0 && outing.Pitches > w.Rules.MaxGame {
+			flags = append(flags, "game")
+		}
+		if w.Rules.MaxDay > 0 && outing.DayPitches > w.Rules.MaxDay {
+			flags = append(flags, "day")
+		}
+		if w.Rules.MaxThreeDays > 0 && outing.DaysPitches3 > w.Rules.MaxThreeDays {
+			flags = append(flags, "3day")
+		}
+		if rest, required := w.getRest(before, outing.Date); rest >= 0 {
+			outing.DaysRest = rest
+			if rest < required {
+				flags = append(flags, "rest")
+			}
+		}
+		outing.Flags = strings.Join(flags, " ")
+		previous[key] = append(before, outing)
+	}
+}
+
+// pitchesSince returns the pitches thrown from days before date through date.
+func (w *Workload) pitchesSince(outings []*Outing, date time.Time, days int) int {
+	start := date.AddDate(0, 0, -days)
+	pitches := 0
+	for _, outing := range outings {
+		if !outing.Date.Before(start) && !outing.Date.After(date) {
+			pitches += outing.Pitches
+		}
+	}
+	return pitches
+}
+
+// getRest returns the full days of rest before date since the last day
+// pitched, and the rest required by that day's pitches.  The rest is -1
+// if the pitcher has not pitched before date.
+func (w *Workload) getRest(outings []*Outing, date time.Time) (rest int, required int) {
+	rest = -1
+	var last time.Time
+	for _, outing := range outings {
+		if outing.Date.Before(date) {
+			last = outing.Date
+		}
+	}
+	if last.IsZero() {
+		return
+	}
+	rest = daysBetween(last, date) - 1
+	required = w.Rules.GetRequiredRest(w.pitchesSince(outings, last, 0))
+	return
+}
+
+func daysBetween(d1, d2 time.Time) int {
+	return int(d2.Sub(d1).Hours()/24 + 0.5)
+}
+
+// Forecast returns the availability of each pitcher of team for a game
+// on date.
+func (w *Workload) Forecast(team string, date time.Time) []*Availability {
+	var (
+		keys     []pitcherKey
+		byPlayer = map[pitcherKey][]*Outing{}
+	)
+	for _, outing := range w.Outings {
+		if !strings.HasPrefix(strings.ToLower(outing.Team), strings.ToLower(team)) ||
+			outing.Date.After(date) {
+			continue
+		}
+		key := pitcherKey{outing.Team, outing.PlayerID}
+		if byPlayer[key] == nil {
+			keys = append(keys, key)
+		}
+		byPlayer[key] = append(byPlayer[key], outing)
+	}
+	var res []*Availability
+	for _, key := range keys {
+		outings := byPlayer[key]
+		last := outings[len(outings)-1]
+		av := &Availability{
+			Team:         key.team,
+			Name:         last.Name,
+			LastPitched:  last.Date,
+			DayPitches:   w.pitchesSince(outings, date, 0),
+			DaysPitches3: w.pitchesSince(outings, date, 2),
+			Available:    true,
+			Remaining:    -1,
+		}
+		av.DaysRest, av.RestRequired = w.getRest(outings, date)
+		limit := func(max, used int, reason string) {
+			if max <= 0 {
+				return
+			}
+			left := max - used
+			if left <= 0 {
+				av.Available = false
+				av.Reason = reason
+				left = 0
+			}
+			if av.Remaining < 0 || left < av.Remaining {
+				av.Remaining = left
+			}
+		}
+		if av.DayPitches > 0 {
+			av.DaysRest = -1
+			av.RestRequired = 0
+		}
+		limit(w.Rules.MaxGame, 0, "game")
+		limit(w.Rules.MaxDay, av.DayPitches, "day limit")
+		limit(w.Rules.MaxThreeDays, av.DaysPitches3, "3 day limit")
+		if av.DaysRest >= 0 && av.DaysRest < av.RestRequired {
+			av.Available = false
+			av.Remaining = 0
+			av.Reason = fmt.Sprintf("needs %d days rest", av.RestRequired)
+		}
+		res = append(res, av)
+	}
+	return res
+}
+
+func (w *Workload) GetData() *dataframe.Data {
+	var (
+		date       = dataframe.NewColumn("Date", "%-10s", dataframe.EmptyStrings)
+		tournament = dataframe.NewColumn("Tournament", "%-20s", dataframe.EmptyStrings)
+		gameID     = dataframe.NewColumn("Game", "%-10s", dataframe.EmptyStrings)
+		team       = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		name       = dataframe.NewColumn("Name", "%-12s", dataframe.EmptyStrings)
+		pitches    = dataframe.NewColumn("PC", "%3d", dataframe.EmptyInts)
+		bf         = dataframe.NewColumn("BF", "%3d", dataframe.EmptyInts)
+		day        = dataframe.NewColumn("Day", "%3d", dataframe.EmptyInts)
+		days3      = dataframe.NewColumn("3Day", "%4d", dataframe.EmptyInts)
+		rest       = dataframe.NewColumn("Rest", "%4s", dataframe.EmptyStrings)
+		flags      = dataframe.NewColumn("Flags", "%-10s", dataframe.EmptyStrings)
+	)
+	for _, outing := range w.Outings {
+		date.AppendString(outing.Date.Format("01/02/2006"))
+		tournament.AppendString(outing.Tournament)
+		gameID.AppendString(outing.GameID)
+		team.AppendString(outing.Team)
+		name.AppendString(outing.Name)
+		pitches.AppendInt(outing.Pitches)
+		bf.AppendInt(outing.BattersFaced)
+		day.AppendInt(outing.DayPitches)
+		days3.AppendInt(outing.DaysPitches3)
+		if outing.DaysRest >= 0 {
+			rest.AppendString(strconv.Itoa(outing.DaysRest))
+		} else {
+			rest.AppendString("")
+		}
+		flags.AppendString(outing.Flags)
+	}
+	return &dataframe.Data{
+		Name: "Pitcher Workload",
+		Columns: []*dataframe.Column{date, tournament, gameID, team, name,
+			pitches, bf, day, days3, rest, flags},
+	}
+}
+
+func GetAvailabilityData(avs []*Availability) *dataframe.Data {
+	var (
+		team      = dataframe.NewColumn("Team", "%-12s", dataframe.EmptyStrings)
+		name      = dataframe.NewColumn("Name", "%-12s", dataframe.EmptyStrings)
+		last      = dataframe.NewColumn("Last", "%-10s", dataframe.EmptyStrings)
+		day       = dataframe.NewColumn("Day", "%3d", dataframe.EmptyInts)
+		days3     = dataframe.NewColumn("3Day", "%4d", dataframe.EmptyInts)
+		rest      = dataframe.NewColumn("Rest", "%4s", dataframe.EmptyStrings)
+		available = dataframe.NewColumn("Avail", "%-5s", dataframe.EmptyStrings)
+		remaining = dataframe.NewColumn("Left", "%4s", dataframe.EmptyStrings)
+		reason    = dataframe.NewColumn("Reason", "%-20s", dataframe.EmptyStrings)
+	)
+	for _, av := range avs {
+		team.AppendString(av.Team)
+		name.AppendString(av.Name)
+		last.AppendString(av.LastPitched.Format("01/02/2006"))
+		day.AppendInt(av.DayPitches)
+		days3.AppendInt(av.DaysPitches3)
+		if av.DaysRest >= 0 {
+			rest.AppendString(fmt.Sprintf("%d/%d", av.DaysRest, av.RestRequired))
+		} else {
+			rest.AppendString("")
+		}
+		if av.Available {
+			available.AppendString("yes")
+		} else {
+			available.AppendString("no")
+		}
+		if av.Remaining >= 0 {
+			remaining.AppendString(strconv.Itoa(av.Remaining))
+		} else {
+			remaining.AppendString("-")
+		}
+		reason.AppendString(av.Reason)
+	}
+	return &dataframe.Data{
+		Name: "Availability",
+		Columns: []*dataframe.Column{team, name, last, day, days3, rest,
+			available, remaining, reason},
+	}
+}
@@ -0,0 +1,62 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseWorkloadGame(t *testing.T, date string) *game.Game {
+	gf, err := gamefile.ParseString("game.gm", `date: `+date+`
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 10
+1 1 BBSSS K
+2 2 BBX 63/G6
+3 3 X 8/F8
+`)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return g
+}
+
+func TestWorkload(t *testing.T) {
+	assert := assert.New(t)
+	games := []*game.Game{
+		parseWorkloadGame(t, "5/22/22"),
+		parseWorkloadGame(t, "5/21/22"),
+	}
+	rest, err := ParseRestRules("10:2, 5:1")
+	assert.NoError(err)
+	assert.Equal([]RestRule{{5, 1}, {10, 2}}, rest)
+	w := NewWorkload(RestRules{MaxThreeDays: 15, Rest: rest}, games)
+	if assert.Len(w.Outings, 2) {
+		first, second := w.Outings[0], w.Outings[1]
+		assert.Equal(9, first.Pitches)
+		assert.Equal(3, first.BattersFaced)
+		assert.Equal("", first.Flags)
+		assert.Equal(9, second.DayPitches)
+		assert.Equal(18, second.DaysPitches3)
+		assert.Equal(0, second.DaysRest)
+		assert.Equal("3day rest", second.Flags)
+	}
+	avs := w.Forecast("b", time.Date(2022, 5, 24, 0, 0, 0, 0, time.UTC))
+	if assert.Len(avs, 1) {
+		assert.True(avs[0].Available)
+		assert.Equal(1, avs[0].DaysRest)
+		assert.Equal(1, avs[0].RestRequired)
+		assert.Equal(6, avs[0].Remaining)
+	}
+	assert.Equal(2, w.GetData().RowCount())
+}
@@ -0,0 +1,77 @@
+package ui
+
+import "sort"
+
+// showCursorError shows the errors of the line under the cursor, or all
+// of the errors if the line doesn't have any.
+func (ui *UI) showCursorError() {
+	for _, area := range []*LinedTextArea{ui.properties, ui.visitorPlays, ui.homePlays} {
+		if area.HasFocus() {
+			row, _, _, _ := area.GetCursor()
+			ui.showLineError(area, row)
+			return
+		}
+	}
+}
+
+func (ui *UI) showLineError(area *LinedTextArea, line int) {
+	if msg := area.LineMessages[line]; msg != "" {
+		ui.messages.SetText(msg)
+	} else {
+		ui.messages.SetText(ui.errorText)
+	}
+}
+
+// gotoError moves the cursor to the next (dir is 1) or previous (dir is
+// -1) line with an error, going from the properties to the visitor plays
+// to the home plays and around.
+func (ui *UI) gotoError(dir int) {
+	type errorLine struct {
+		area int
+		line int
+	}
+	areas := []*LinedTextArea{ui.properties, ui.visitorPlays, ui.homePlays}
+	var (
+		lines   []errorLine
+		current = errorLine{area: -1}
+	)
+	for i, area := range areas {
+		for line := range area.LineMessages {
+			lines = append(lines, errorLine{area: i, line: line})
+		}
+		if area.HasFocus() {
+			row, _, _, _ := area.GetCursor()
+			current = errorLine{area: i, line: row}
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	less := func(a, b errorLine) bool {
+		return a.area < b.area || (a.area == b.area && a.line < b.line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return less(lines[i], lines[j])
+	})
+	next := lines[0]
+	if dir < 0 {
+		next = lines[len(lines)-1]
+		for i := len(lines) - 1; i >= 0; i-- {
+			if less(lines[i], current) {
+				next = lines[i]
+				break
+			}
+		}
+	} else {
+		for _, l := range lines {
+			if less(current, l) {
+				next = l
+				break
+			}
+		}
+	}
+	area := areas[next.area]
+	ui.app.SetFocus(area)
+	area.SelectLine(next.line)
+	ui.showLineError(area, next.line)
+}
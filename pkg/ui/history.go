@@ -0,0 +1,120 @@
+package ui
+
+import "time"
+
+// snapshot is the text of all of the editing areas.
+type snapshot struct {
+	properties, visitorPlays, homePlays string
+}
+
+// History is the undo and redo stacks of the editing areas.  Since each
+// entry is the text of every area, undoing a change that moved text
+// between areas, like swapping home and away, restores all of them.
+type History struct {
+	undo, redo []snapshot
+	// Max is the number of changes that can be undone
+	Max int
+}
+
+func NewHistory() *History {
+	return &History{Max: 200}
+}
+
+// Reset forgets all of the changes.
+func (h *History) Reset() {
+	h.undo = nil
+	h.redo = nil
+}
+
+// Save records s as the text before a change.
+func (h *History) Save(s snapshot) {
+	if n := len(h.undo); n > 0 && h.undo[n-1] == s {
+		return
+	}
+	h.undo = append(h.undo, s)
+	if len(h.undo) > h.Max {
+		h.undo = h.undo[len(h.undo)-h.Max:]
+	}
+	h.redo = nil
+}
+
+// Undo returns the text before the last change, given the current text.
+func (h *History) Undo(current snapshot) (snapshot, bool) {
+	for len(h.undo) > 0 {
+		s := h.undo[len(h.undo)-1]
+		h.undo = h.undo[:len(h.undo)-1]
+		if s != current {
+			h.redo = append(h.redo, current)
+			return s, true
+		}
+	}
+	return current, false
+}
+
+// Redo returns the text after the last change undone, given the current
+// text.
+func (h *History) Redo(current snapshot) (snapshot, bool) {
+	if len(h.redo) == 0 {
+		return current, false
+	}
+	s := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, current)
+	return s, true
+}
+
+func (ui *UI) getSnapshot() snapshot {
+	return snapshot{
+		properties:   ui.properties.GetText(),
+		visitorPlays: ui.visitorPlays.GetText(),
+		homePlays:    ui.homePlays.GetText(),
+	}
+}
+
+// getSnapshotText returns the game file text of the editing areas.
+func (ui *UI) getSnapshotText() string {
+	s := ui.getSnapshot()
+	text, _, _ := buildGameText(s.properties, s.visitorPlays, s.homePlays)
+	return text
+}
+
+func (ui *UI) restoreSnapshot(s snapshot) {
+	for _, x := range []struct {
+		area *LinedTextArea
+		text string
+	}{
+		{ui.properties, s.properties},
+		{ui.visitorPlays, s.visitorPlays},
+		{ui.homePlays, s.homePlays},
+	} {
+		if x.area.GetText() != x.text {
+			row, _, _, _ := x.area.GetCursor()
+			x.area.Replace(0, x.area.GetTextLength(), x.text)
+			x.area.SelectLine(row)
+		}
+	}
+	ui.lastEdit = time.Time{}
+	ui.setModified()
+	ui.lastKey = time.Now()
+	if ui.scorer != nil {
+		// the plate appearance being scored may have been undone
+		if g, err := ui.parseGameText(ui.getSnapshotText()); err == nil {
+			ui.scorer = NewScorer(g, ui.getScoringHalf())
+			ui.showScorer()
+		} else {
+			ui.stopScoring()
+		}
+	}
+}
+
+func (ui *UI) undo() {
+	if s, ok := ui.history.Undo(ui.getSnapshot()); ok {
+		ui.restoreSnapshot(s)
+	}
+}
+
+func (ui *UI) redo() {
+	if s, ok := ui.history.Redo(ui.getSnapshot()); ok {
+		ui.restoreSnapshot(s)
+	}
+}
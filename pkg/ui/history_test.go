@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHistory()
+	a := snapshot{properties: "date: 5/21/22\n"}
+	b := snapshot{properties: a.properties, visitorPlays: "1 1 X S8/G8\n"}
+	// swapping moves the plays between areas
+	c := snapshot{properties: a.properties, homePlays: b.visitorPlays}
+	h.Save(a)
+	h.Save(b)
+	h.Save(b)
+	s, ok := h.Undo(c)
+	assert.True(ok)
+	assert.Equal(b, s)
+	s, ok = h.Undo(s)
+	assert.True(ok)
+	assert.Equal(a, s)
+	_, ok = h.Undo(s)
+	assert.False(ok)
+	s, ok = h.Redo(s)
+	assert.True(ok)
+	assert.Equal(b, s)
+	s, ok = h.Redo(s)
+	assert.True(ok)
+	assert.Equal(c, s)
+	_, ok = h.Redo(s)
+	assert.False(ok)
+	// a new change can't be redone past
+	s, _ = h.Undo(s)
+	h.Save(s)
+	_, ok = h.Redo(s)
+	assert.False(ok)
+}
+
+func TestJournal(t *testing.T) {
+	assert := assert.New(t)
+	gamePath := filepath.Join(t.TempDir(), "20220521-1.gm")
+	text, _ := readJournal(gamePath)
+	assert.Empty(text)
+	assert.NoError(writeJournal(gamePath, "date: 5/21/22\n"))
+	assert.NoError(writeJournal(gamePath, "date: 5/22/22\n"))
+	text, saved := readJournal(gamePath)
+	assert.Equal("date: 5/22/22\n", text)
+	assert.False(saved.IsZero())
+	entries, _ := os.ReadDir(filepath.Dir(gamePath))
+	assert.Len(entries, 1)
+	removeJournal(gamePath)
+	text, _ = readJournal(gamePath)
+	assert.Empty(text)
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// getJournalPath returns the path of the file the unsaved text of the
+// game at gamePath is autosaved to.
+func getJournalPath(gamePath string) string {
+	return filepath.Join(filepath.Dir(gamePath), fmt.Sprintf(".%s.journal", filepath.Base(gamePath)))
+}
+
+// writeJournal autosaves text for gamePath.  The journal is replaced by
+// renaming a new file so that a crash while writing leaves the last one.
+func writeJournal(gamePath string, text string) error {
+	journalPath := getJournalPath(gamePath)
+	f, err := os.CreateTemp(filepath.Dir(journalPath), filepath.Base(journalPath)+"*")
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(text)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), journalPath)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+// readJournal returns the autosaved text for gamePath and when it was
+// saved, or an empty string if there isn't any.
+func readJournal(gamePath string) (string, time.Time) {
+	journalPath := getJournalPath(gamePath)
+	fi, err := os.Stat(journalPath)
+	if err != nil {
+		return "", time.Time{}
+	}
+	text, err := os.ReadFile(journalPath)
+	if err != nil {
+		return "", time.Time{}
+	}
+	return string(text), fi.ModTime()
+}
+
+func removeJournal(gamePath string) {
+	_ = os.Remove(getJournalPath(gamePath))
+}
+
+// autosaveInterval is how often unsaved changes are written to the
+// journal
+const autosaveInterval = 5 * time.Second
+
+// autosave writes the text of a modified game to its journal, so that it
+// can be recovered if the UI exits without saving.  The journal is
+// written from the UI goroutine so that it can't race with a save.
+func (ui *UI) autosave(ticker *time.Ticker, done <-chan bool) {
+	var lastText string
+	for {
+		select {
+		case <-ticker.C:
+			ui.app.QueueUpdate(func() {
+				if !ui.modified {
+					return
+				}
+				text := ui.getSnapshotText()
+				if _, err := os.Stat(getJournalPath(ui.path)); err == nil && text == lastText {
+					return
+				}
+				if err := writeJournal(ui.path, text); err != nil {
+					ui.Logger.Println("cannot write journal: ", err)
+				} else {
+					lastText = text
+				}
+			})
+		case <-done:
+			return
+		}
+	}
+}
+
+func (ui *UI) offerRecovery(text string, saved time.Time) {
+	gamePath := ui.path
+	modal := tview.NewModal().AddButtons([]string{"Recover", "Discard"}).
+		SetText(fmt.Sprintf("%s has unsaved changes from %s. Recover them?",
+			filepath.Base(gamePath), saved.Format("Jan 2 15:04"))).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.closeDialog()
+			if buttonLabel == "Recover" {
+				ui.history.Save(ui.getSnapshot())
+				ui.loadGameText(strings.NewReader(text))
+				ui.setModified()
+				ui.lastKey = time.Now()
+			} else {
+				removeJournal(gamePath)
+			}
+		})
+	ui.showDialog(modal)
+}
@@ -1,39 +1,133 @@
 package ui
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
 	"github.com/rivo/tview"
+	"github.com/slshen/paperscore/pkg/gamefile"
 )
 
 // TextArea doesn't provide GetSelectedStyle() so just assume it's the default
 var defaultSelectedStyle = tcell.StyleDefault.Background(tview.Styles.PrimaryTextColor).
 	Foreground(tview.Styles.PrimitiveBackgroundColor)
 
+var highlightColors = map[gamefile.HighlightKind]tcell.Color{
+	gamefile.HighlightKey:             tcell.ColorTeal,
+	gamefile.HighlightPlateAppearance: tcell.ColorGray,
+	gamefile.HighlightBatter:          tcell.ColorTeal,
+	gamefile.HighlightPitches:         tcell.ColorGreen,
+	gamefile.HighlightPlayCode:        tcell.ColorYellow,
+	gamefile.HighlightAdvance:         tcell.ColorFuchsia,
+	gamefile.HighlightKeyword:         tcell.ColorTeal,
+	gamefile.HighlightComment:         tcell.ColorGray,
+}
+
+// errorMarker is drawn on the left border of a line with an error
+const errorMarker = '●'
+
 type LinedTextArea struct {
 	*tview.TextArea
-	LineColors map[int]*tcell.Color
+	LineColors   map[int]*tcell.Color
+	LineMessages map[int]string
+	// Plays is true if the text is the plays of a game file rather than
+	// the properties
+	Plays bool
+
+	highlightedText  string
+	highlightedLines []string
+	highlights       map[int][]gamefile.Highlight
 }
 
 func NewLinedtextArea() *LinedTextArea {
 	t := &LinedTextArea{
-		TextArea:   tview.NewTextArea(),
-		LineColors: map[int]*tcell.Color{},
+		// lines aren't wrapped so that each row is a line of the text
+		TextArea:     tview.NewTextArea().SetWrap(false),
+		LineColors:   map[int]*tcell.Color{},
+		LineMessages: map[int]string{},
 	}
 	return t
 }
 
+// ClearColors removes the colors and messages of the lines.
 func (t *LinedTextArea) ClearColors() {
 	for k := range t.LineColors {
 		delete(t.LineColors, k)
 	}
+	for k := range t.LineMessages {
+		delete(t.LineMessages, k)
+	}
+}
+
+// GetLineAt returns the line of the text at screen row y, or -1 if y is
+// outside the text area.
+func (t *LinedTextArea) GetLineAt(y int) int {
+	firstLine, _ := t.GetOffset()
+	_, top, _, height := t.GetInnerRect()
+	if y < top || y >= top+height {
+		return -1
+	}
+	return firstLine + y - top
+}
+
+// SelectLine moves the cursor to the start of line.
+func (t *LinedTextArea) SelectLine(line int) {
+	offset := 0
+	text := t.GetText()
+	for i := 0; i < line && offset < len(text); i++ {
+		for offset < len(text) && text[offset] != '\n' {
+			offset++
+		}
+		offset++
+	}
+	t.Select(offset, offset)
+}
+
+func (t *LinedTextArea) getHighlights() map[int][]gamefile.Highlight {
+	text := t.GetText()
+	if t.highlights == nil || text != t.highlightedText {
+		t.highlightedText = text
+		t.highlightedLines = strings.Split(text, "\n")
+		t.highlights = map[int][]gamefile.Highlight{}
+		for _, h := range gamefile.GetHighlights(text, t.Plays) {
+			t.highlights[h.Pos.Line-1] = append(t.highlights[h.Pos.Line-1], h)
+		}
+	}
+	return t.highlights
+}
+
+// getCells returns the screen column and width of the highlight h in
+// line, since the position and length of a highlight are in bytes.
+func getCells(line string, h gamefile.Highlight) (int, int) {
+	start := min(max(h.Pos.Column-1, 0), len(line))
+	end := min(start+h.Length, len(line))
+	return runewidth.StringWidth(line[:start]), runewidth.StringWidth(line[start:end])
 }
 
 func (t *LinedTextArea) Draw(screen tcell.Screen) {
 	t.TextArea.Draw(screen)
-	firstLine, _ := t.GetOffset()
+	firstLine, firstColumn := t.GetOffset()
 	x, y, width, height := t.GetInnerRect()
+	highlights := t.getHighlights()
 	for row := y; row < y+height; row++ {
 		line := firstLine + row - y
+		for _, h := range highlights[line] {
+			color, ok := highlightColors[h.Kind]
+			if !ok {
+				continue
+			}
+			start, length := getCells(t.highlightedLines[line], h)
+			for col := start - firstColumn; col < start-firstColumn+length; col++ {
+				if col < 0 || col >= width {
+					continue
+				}
+				ch, chc, chStyle, _ := screen.GetContent(x+col, row)
+				if chStyle != defaultSelectedStyle {
+					screen.SetContent(x+col, row, ch, chc, chStyle.Foreground(color))
+				}
+			}
+		}
 		lineColor := t.LineColors[line]
 		if lineColor != nil {
 			for col := x; col < x+width; {
@@ -47,5 +141,9 @@ func (t *LinedTextArea) Draw(screen tcell.Screen) {
 				col += chw
 			}
 		}
+		if t.LineMessages[line] != "" && x > 0 {
+			_, _, borderStyle, _ := screen.GetContent(x-1, row)
+			screen.SetContent(x-1, row, errorMarker, nil, borderStyle.Foreground(tcell.ColorRed))
+		}
 	}
 }
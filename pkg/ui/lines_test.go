@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCells(t *testing.T) {
+	assert := assert.New(t)
+	h := gamefile.Highlight{Pos: gamefile.Position{Line: 1, Column: 3}, Length: 1}
+	start, width := getCells("1 K", h)
+	assert.Equal(2, start)
+	assert.Equal(1, width)
+	// é is 2 bytes in 1 cell, 中 is 3 bytes in 2 cells
+	line := "é 中 X"
+	h = gamefile.Highlight{Pos: gamefile.Position{Line: 1, Column: 4}, Length: 3}
+	start, width = getCells(line, h)
+	assert.Equal(2, start)
+	assert.Equal(2, width)
+	h = gamefile.Highlight{Pos: gamefile.Position{Line: 1, Column: 8}, Length: 1}
+	start, width = getCells(line, h)
+	assert.Equal(5, start)
+	assert.Equal(1, width)
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+)
+
+// startScoring starts scoring pitch by pitch into the visitor or home
+// plays, whichever has the focus.
+func (ui *UI) startScoring() {
+	switch {
+	case ui.visitorPlays.HasFocus():
+		ui.scoringPlays = ui.visitorPlays
+	case ui.homePlays.HasFocus():
+		ui.scoringPlays = ui.homePlays
+	default:
+		ui.messages.SetText("move to the visitor or home plays to score pitches")
+		return
+	}
+	g, err := ui.parseGameText(ui.getGameText())
+	if err != nil {
+		ui.scoringPlays = nil
+		ui.messages.SetText(fmt.Sprintf("cannot score pitches until the game is fixed: %s", err))
+		return
+	}
+	ui.scorer = NewScorer(g, ui.getScoringHalf())
+	ui.showScorer()
+}
+
+func (ui *UI) stopScoring() {
+	ui.scorer = nil
+	ui.scoringPlays = nil
+	ui.help.SetText(helpText)
+}
+
+func (ui *UI) getScoringHalf() game.Half {
+	if ui.scoringPlays == ui.visitorPlays {
+		return game.Top
+	}
+	return game.Bottom
+}
+
+func (ui *UI) showScorer() {
+	ui.help.SetText(fmt.Sprintf("%s  %s", pitchesHelpText, ui.scorer))
+}
+
+func (ui *UI) parseGameText(text string) (*game.Game, error) {
+	gf, err := gamefile.ParseString(ui.path, text)
+	if err != nil {
+		return nil, err
+	}
+	return game.NewGame(gf)
+}
+
+// handleScoringKey adds the pitch for a key, or prompts for the play when
+// the pitch ends the plate appearance.  Keys that would edit the plays
+// are ignored.
+func (ui *UI) handleScoringKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyCtrlG, tcell.KeyEscape:
+		ui.stopScoring()
+		return nil
+	case tcell.KeyEnter:
+		// a play between pitches, e.g. a stolen base
+		ui.promptPlay("", false)
+		return nil
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		ui.scorer.RemovePitch()
+		ui.showScorer()
+		return nil
+	case tcell.KeyRune:
+		pitch := unicode.ToUpper(event.Rune())
+		if strings.ContainsRune("BCSFXH", pitch) {
+			code := ui.scorer.AddPitch(pitch)
+			ui.showScorer()
+			if code != "" || pitch == 'X' {
+				ui.promptPlay(code, true)
+			}
+		}
+		return nil
+	case tcell.KeyDelete:
+		return nil
+	}
+	return event
+}
+
+// promptPlay asks for the play and writes it.  If the prompt is cancelled
+// and undoPitch is true, the pitch that brought up the prompt is removed.
+func (ui *UI) promptPlay(code string, undoPitch bool) {
+	setFocus := func(p tview.Primitive) {
+		ui.app.SetFocus(p)
+	}
+	ui.showDialog(NewPlayPrompt(ui.scorer, code, setFocus, func(batter, play string) {
+		if play == "" {
+			if undoPitch {
+				ui.scorer.RemovePitch()
+			}
+		} else {
+			if batter != "" {
+				ui.scorer.Batter = batter
+			}
+			if err := ui.writePlay(play); err != nil {
+				ui.messages.SetText(err.Error())
+				return
+			}
+		}
+		ui.closeDialog()
+		ui.app.SetFocus(ui.scoringPlays)
+		ui.showScorer()
+	}))
+}
+
+// writePlay appends the line for play to the plays being scored if the
+// game is still valid with it, and moves on to the next play.
+func (ui *UI) writePlay(play string) error {
+	if ui.scorer.Batter == "" && !ui.scorer.Continued {
+		return fmt.Errorf("who is batting?")
+	}
+	plays := ui.scoringPlays.GetText()
+	if plays != "" && !strings.HasSuffix(plays, "\n") {
+		plays += "\n"
+	}
+	plays += ui.scorer.GetLine(play) + "\n"
+	visitorPlays, homePlays := ui.visitorPlays.GetText(), ui.homePlays.GetText()
+	if ui.scoringPlays == ui.visitorPlays {
+		visitorPlays = plays
+	} else {
+		homePlays = plays
+	}
+	text, _, _ := buildGameText(ui.properties.GetText(), visitorPlays, homePlays)
+	g, err := ui.parseGameText(text)
+	if err != nil {
+		return err
+	}
+	ui.history.Save(ui.getSnapshot())
+	ui.scoringPlays.Replace(0, ui.scoringPlays.GetTextLength(), plays)
+	ui.scorer = NewScorer(g, ui.getScoringHalf())
+	ui.setModified()
+	ui.lastKey = time.Now()
+	return nil
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// PlayPrompt asks for the batter and the play code with advances of a
+// plate appearance, completing the play code as it's typed.
+type PlayPrompt struct {
+	*tview.Grid
+	batter *tview.InputField
+	play   *tview.InputField
+}
+
+// NewPlayPrompt returns a prompt for the play of scorer.  The play is
+// prefilled with code, if any, and the advances that go with it.  done is
+// called with the batter and play when Enter is pressed on the play, or
+// with empty strings if the prompt is cancelled.  setFocus moves the
+// application focus from the batter to the play.
+func NewPlayPrompt(scorer *Scorer, code string, setFocus func(tview.Primitive),
+	done func(batter, play string)) *PlayPrompt {
+	p := &PlayPrompt{
+		Grid:   tview.NewGrid(),
+		batter: tview.NewInputField().SetLabel("Batter ").SetText(scorer.Batter),
+		play:   tview.NewInputField().SetLabel("Play   "),
+	}
+	if code != "" {
+		p.play.SetText(strings.Join(append([]string{code}, scorer.GetAdvances(code)...), " "))
+	}
+	p.play.SetAutocompleteFunc(func(text string) []string {
+		if text == "" || strings.Contains(text, " ") {
+			return nil
+		}
+		var entries []string
+		for _, code := range GetPlayCodes(text) {
+			entries = append(entries, strings.Join(append([]string{code}, scorer.GetAdvances(code)...), " "))
+		}
+		return entries
+	})
+	cancel := func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			done("", "")
+			return nil
+		}
+		return event
+	}
+	p.batter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter || key == tcell.KeyTab {
+			setFocus(p.play)
+		}
+	})
+	p.batter.SetInputCapture(cancel)
+	p.play.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			done(strings.TrimSpace(p.batter.GetText()), strings.TrimSpace(p.play.GetText()))
+		}
+	})
+	p.play.SetInputCapture(cancel)
+	form := tview.NewFlex().SetDirection(tview.FlexRow)
+	if !scorer.Continued {
+		form.AddItem(p.batter, 1, 0, false)
+	}
+	form.AddItem(p.play, 1, 0, true)
+	form.SetBorder(true).SetTitle(scorer.String() + " (ESC cancels)")
+	p.SetColumns(0, 50, 0).SetRows(0, 4, 0).AddItem(form, 1, 1, 1, 1, 0, 0, true)
+	return p
+}
+
+func (p *PlayPrompt) Focus(delegate func(p tview.Primitive)) {
+	// start with the batter if the lineup doesn't say who is up
+	if p.batter.GetText() == "" {
+		delegate(p.batter)
+	} else {
+		delegate(p.play)
+	}
+}
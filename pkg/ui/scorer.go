@@ -0,0 +1,294 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/game"
+)
+
+// Scorer keeps track of the plate appearance being scored pitch by pitch
+// for the batting team of one half of a game, and writes the game file
+// line for it.
+type Scorer struct {
+	Team *game.Team
+	// PA is the number of the plate appearance
+	PA int
+	// Batter is the batter as written in the game file, empty if the
+	// lineup doesn't say who is up
+	Batter string
+	// Continued is true if the plate appearance was started on an
+	// earlier line, e.g. by a stolen base
+	Continued bool
+	// Pitches are the pitches entered for the plate appearance that
+	// have not been written yet
+	Pitches game.Pitches
+
+	last   *game.State
+	lineup []game.PlayerID
+}
+
+// NewScorer returns a scorer for the next plate appearance of the team
+// batting in half.
+func NewScorer(g *game.Game, half game.Half) *Scorer {
+	s := &Scorer{Team: g.Home}
+	states := g.GetHomeStates()
+	if half == game.Top {
+		s.Team = g.Visitor
+		states = g.GetVisitorStates()
+	}
+	slot := s.readLineup(states)
+	for _, state := range states {
+		if state.PlateAppearance.Number >= s.PA {
+			s.PA = state.PlateAppearance.Number
+		}
+	}
+	if len(states) > 0 {
+		s.last = states[len(states)-1]
+	}
+	switch {
+	case s.last == nil:
+		s.PA = 1
+	case s.last.Complete:
+		s.PA++
+		if len(s.lineup) > 0 && slot >= 0 {
+			s.Batter = s.getBatterNumber(s.lineup[(slot+1)%len(s.lineup)])
+		}
+	case s.last.Incomplete:
+		// the batter was up when the inning ended, so leads off
+		s.PA++
+		s.Batter = s.getBatterNumber(s.last.Batter)
+	default:
+		s.Continued = true
+		s.Batter = s.getBatterNumber(s.last.Batter)
+	}
+	return s
+}
+
+// readLineup reads the batting order from the order of the batters in
+// states, and returns the lineup slot of the last batter.  Until the
+// order turns over the slot is -1 after a completed plate appearance,
+// since the next batter isn't known.
+func (s *Scorer) readLineup(states []*game.State) int {
+	var (
+		complete bool
+		slot     = -1
+		last     *game.State
+	)
+	for _, state := range states {
+		newPA := last == nil || last.Complete
+		last = state
+		if !newPA {
+			continue
+		}
+		if complete {
+			slot = (slot + 1) % len(s.lineup)
+			// a substitute takes over the slot
+			s.lineup[slot] = state.Batter
+			continue
+		}
+		i := indexOf(s.lineup, state.Batter)
+		if i >= 0 {
+			complete = true
+			slot = i
+		} else {
+			s.lineup = append(s.lineup, state.Batter)
+			slot = len(s.lineup) - 1
+		}
+	}
+	if !complete && last != nil && last.Complete {
+		return -1
+	}
+	return slot
+}
+
+func indexOf(lineup []game.PlayerID, player game.PlayerID) int {
+	for i, p := range lineup {
+		if p == player {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Scorer) getBatterNumber(id game.PlayerID) string {
+	if p := s.Team.Players[id]; p != nil && p.Number != "" {
+		return p.Number
+	}
+	return string(id)
+}
+
+// Count returns the balls and strikes on the batter.
+func (s *Scorer) Count() (balls, strikes int) {
+	_, _, balls, strikes = s.getPitches().Count()
+	return
+}
+
+func (s *Scorer) getPitches() game.Pitches {
+	if s.Continued {
+		return s.last.Pitches + s.Pitches
+	}
+	return s.Pitches
+}
+
+// AddPitch adds a pitch and returns the play code if the pitch ends the
+// plate appearance by itself, i.e. a walk, a strike out, or a hit by
+// pitch.  A ball put in play (X) returns an empty code since it's up to
+// the scorer what happened.
+func (s *Scorer) AddPitch(pitch rune) string {
+	s.Pitches += game.Pitches(pitch)
+	balls, strikes := s.Count()
+	switch {
+	case pitch == 'H':
+		return "HP"
+	case pitch == 'B' && balls == 4:
+		return "W"
+	case pitch != 'X' && strikes == 3:
+		return "K"
+	}
+	return ""
+}
+
+// RemovePitch removes the last pitch added.
+func (s *Scorer) RemovePitch() {
+	if len(s.Pitches) > 0 {
+		s.Pitches = s.Pitches[:len(s.Pitches)-1]
+	}
+}
+
+// GetRunners returns the runners on base before the next play.
+func (s *Scorer) GetRunners() [3]game.PlayerID {
+	if s.last == nil || s.last.Outs == 3 {
+		return [3]game.PlayerID{}
+	}
+	return s.last.Runners
+}
+
+var (
+	forcedAdvanceCodeRegexp = regexp.MustCompile(`^(W|IW|HP|C|E[0-9]|FC[0-9]|K\+WP|K\+PB|W\+WP|W\+PB)$`)
+	hitCodeRegexp           = regexp.MustCompile(`^([SDTH])[0-9]?$|^(DGR)$`)
+)
+
+// GetAdvances returns the advances of the runners on base that usually go
+// with code: on a hit each runner moves up as many bases as the batter,
+// and when the batter is awarded first the runners who are forced move up
+// one base.  The batter's advance is implied by the code so it is not
+// included.
+func (s *Scorer) GetAdvances(code string) []string {
+	code, _, _ = strings.Cut(code, "/")
+	bases := 0
+	if m := hitCodeRegexp.FindStringSubmatch(code); m != nil {
+		bases = map[string]int{"S": 1, "D": 2, "DGR": 2, "T": 3, "H": 4}[m[1]+m[2]]
+	}
+	runners := s.GetRunners()
+	var advances []string
+	forced := forcedAdvanceCodeRegexp.MatchString(code)
+	for i := 2; i >= 0; i-- {
+		if runners[i] == "" {
+			continue
+		}
+		to := i + bases
+		if forced && isForced(runners, i) {
+			to = i + 1
+		}
+		if to <= i {
+			continue
+		}
+		advances = append(advances, fmt.Sprintf("%d-%s", i+1, baseName(to)))
+	}
+	return advances
+}
+
+// isForced returns true if the runner on base is forced to move up when
+// the batter is awarded first, i.e. every base behind the runner is
+// occupied.
+func isForced(runners [3]game.PlayerID, base int) bool {
+	for i := 0; i < base; i++ {
+		if runners[i] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func baseName(base int) string {
+	if base >= 3 {
+		return "H"
+	}
+	return fmt.Sprint(base + 1)
+}
+
+// GetLine returns the game file line for the play.
+func (s *Scorer) GetLine(play string) string {
+	pitches := string(s.Pitches)
+	if pitches == "" {
+		// an empty pitch sequence is written as a single dot
+		pitches = "."
+	}
+	if s.Continued {
+		return fmt.Sprintf("... %s %s", pitches, play)
+	}
+	return fmt.Sprintf("%d %s %s %s", s.PA, s.Batter, pitches, play)
+}
+
+// String describes the plate appearance for the status line.
+func (s *Scorer) String() string {
+	batter := s.Batter
+	if batter == "" {
+		batter = "?"
+	}
+	balls, strikes := s.Count()
+	return fmt.Sprintf("PA %d #%s %s %d-%d", s.PA, batter, s.getPitches(), balls, strikes)
+}
+
+// playCodeTemplates are the codes offered for completion, $ stands for
+// each fielder.
+var playCodeTemplates = []string{
+	"S$", "D$", "T$", "H$", "DGR", "E$", "FC$",
+	"$", "$3", "$4", "$6", "63", "43", "53", "13",
+	"64(1)3/GDP", "43(1)6/GDP", "54(1)3/GDP", "63(1)4/GDP",
+	"K", "K+WP", "K+PB", "W", "IW", "HP", "C/E2",
+	"SB2", "SB3", "SBH", "WP", "PB",
+}
+
+var trajectories = []string{"G", "L", "F", "P"}
+
+// GetPlayCodes returns the play codes that start with prefix.  If the
+// prefix has a modifier, i.e. S8/, the trajectories of the play are
+// returned.
+func GetPlayCodes(prefix string) []string {
+	var codes []string
+	if code, _, ok := strings.Cut(prefix, "/"); ok {
+		// the trajectory is to the first fielder to touch the ball
+		fielder := strings.TrimLeft(code, "SDTHEFC")
+		if len(fielder) > 0 {
+			fielder = fielder[:1]
+		}
+		for _, t := range trajectories {
+			c := fmt.Sprintf("%s/%s%s", code, t, fielder)
+			if strings.HasPrefix(c, prefix) {
+				codes = append(codes, c)
+			}
+		}
+		return codes
+	}
+	seen := map[string]bool{}
+	for _, template := range playCodeTemplates {
+		var expanded []string
+		if strings.Contains(template, "$") {
+			for fielder := 1; fielder <= 9; fielder++ {
+				expanded = append(expanded, strings.Replace(template, "$", fmt.Sprint(fielder), 1))
+			}
+		} else {
+			expanded = []string{template}
+		}
+		for _, c := range expanded {
+			if strings.HasPrefix(c, prefix) && !seen[c] {
+				seen[c] = true
+				codes = append(codes, c)
+			}
+		}
+	}
+	return codes
+}
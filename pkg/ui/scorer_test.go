@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScorer(t *testing.T, plays string) *Scorer {
+	gf, err := gamefile.ParseString("20220521-1.gm", `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+`+plays)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	g, err := game.NewGame(gf)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return NewScorer(g, game.Top)
+}
+
+func TestScorer(t *testing.T) {
+	assert := assert.New(t)
+	// the lineup turned over, so 1 is followed by 2
+	s := newTestScorer(t, `1 1 X S8/G8
+2 2 BBBB W B-1 1-2
+3 3 SSS K
+4 1 X 63/G6 1-2 2-3
+`)
+	assert.Equal(5, s.PA)
+	assert.Equal("2", s.Batter)
+	assert.Equal([]string{"3-H", "2-H"}, s.GetAdvances("D7/L7"))
+	// first base is open so nobody is forced
+	assert.Empty(s.GetAdvances("W"))
+	assert.Empty(s.GetAdvances("8/F8"))
+	assert.Equal("", s.AddPitch('B'))
+	assert.Equal("", s.AddPitch('F'))
+	assert.Equal("", s.AddPitch('F'))
+	assert.Equal("", s.AddPitch('F'))
+	assert.Equal("K", s.AddPitch('C'))
+	s.RemovePitch()
+	assert.Equal("5 2 BFFF 8/F8", s.GetLine("8/F8"))
+	assert.Equal("HP", s.AddPitch('H'))
+}
+
+func TestScorerContinued(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestScorer(t, `1 1 X S8/G8
+2 2 BC SB2
+`)
+	assert.True(s.Continued)
+	assert.Equal("2", s.Batter)
+	s.AddPitch('B')
+	balls, strikes := s.Count()
+	assert.Equal(2, balls)
+	assert.Equal(1, strikes)
+	assert.Equal("... B S9/G9 2-3", s.GetLine("S9/G9 2-3"))
+	assert.Equal([]string{"2-3"}, s.GetAdvances("S9"))
+	assert.Empty(s.GetAdvances("W"))
+	s = newTestScorer(t, `1 1 X S8/G8
+2 2 BBBB W B-1 1-2
+`)
+	assert.Equal([]string{"2-3", "1-2"}, s.GetAdvances("W"))
+	// a new batter is not known until the lineup turns over
+	s = newTestScorer(t, `1 1 X S8/G8
+`)
+	assert.Equal(2, s.PA)
+	assert.Equal("", s.Batter)
+}
+
+func TestGetPlayCodes(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal([]string{"S8/G8", "S8/L8", "S8/F8", "S8/P8"}, GetPlayCodes("S8/"))
+	assert.Equal([]string{"K", "K+WP", "K+PB"}, GetPlayCodes("K"))
+	assert.Contains(GetPlayCodes("6"), "63")
+	assert.Contains(GetPlayCodes("6"), "64(1)3/GDP")
+}
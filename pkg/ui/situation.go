@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+)
+
+// showSituation shows the situation after the play on the line under the
+// cursor of the visitor or home plays.
+func (ui *UI) showSituation() {
+	if ui.game == nil {
+		return
+	}
+	var (
+		states []*game.State
+		line   int
+	)
+	switch {
+	case ui.visitorPlays.HasFocus():
+		row, _, _, _ := ui.visitorPlays.GetCursor()
+		states, line = ui.game.GetVisitorStates(), ui.visitorPlaysStart+row+1
+	case ui.homePlays.HasFocus():
+		row, _, _, _ := ui.homePlays.GetCursor()
+		states, line = ui.game.GetHomeStates(), ui.homePlaysStart+row+1
+	default:
+		return
+	}
+	var state *game.State
+	for _, s := range states {
+		if s.Pos.Line > line {
+			break
+		}
+		state = s
+	}
+	if state == nil {
+		ui.situation.SetText("")
+		return
+	}
+	sit := stats.NewSituation(ui.game, state, ui.RE)
+	var season *stats.Batting
+	if ui.season != nil {
+		if ts := ui.season.TeamStats[state.BattingTeam.Name]; ts != nil {
+			season = ts.Batting[state.Batter]
+		}
+	}
+	ui.situation.SetText(formatSituation(sit, season, ui.RE != nil))
+}
+
+func formatSituation(sit *stats.Situation, season *stats.Batting, showRE bool) string {
+	var s strings.Builder
+	state := sit.State
+	outs := "outs"
+	if state.Outs == 1 {
+		outs = "out"
+	}
+	fmt.Fprintf(&s, "%s %d  %d %s  %d runs\n", state.Half, state.InningNumber, state.Outs, outs, state.Score)
+	runner := func(base int) string {
+		name := ""
+		if state.Outs < 3 && state.Runners[base] != "" {
+			name = state.BattingTeam.GetPlayer(state.Runners[base]).NameOrNumber()
+		}
+		return fmt.Sprintf("[%-8.8s]", name)
+	}
+	fmt.Fprintf(&s, "       %s\n", runner(1))
+	fmt.Fprintf(&s, "%s   %s\n", runner(2), runner(0))
+	_, count, _, _ := state.Pitches.Count()
+	fmt.Fprintf(&s, "Count %s %s %s\n", count, state.Pitches, state.PlayCode)
+	fmt.Fprintf(&s, "Batter %s\n", state.BattingTeam.GetPlayer(state.Batter).NameOrNumber())
+	fmt.Fprintf(&s, " today %s\n", formatBattingLine(sit.Batting))
+	if season != nil && season.PA > 0 {
+		fmt.Fprintf(&s, " season %s\n", formatSeasonLine(season))
+	}
+	p := sit.Pitching
+	fmt.Fprintf(&s, "Pitcher %s\n", state.FieldingTeam.GetPlayer(state.Pitcher).NameOrNumber())
+	fmt.Fprintf(&s, " %d P %s IP %d H %d BB %d K\n", p.Pitches, p.IP, p.Hits, p.Walks, p.StrikeOuts)
+	if showRE {
+		fmt.Fprintf(&s, "RE %.3f -> %.3f %+.3f\n", sit.REBefore, sit.REAfter, sit.REChange)
+	}
+	return s.String()
+}
+
+func formatBattingLine(b *stats.Batting) string {
+	line := fmt.Sprintf("%d-%d", b.Hits, b.AB)
+	for _, x := range []struct {
+		n    int
+		name string
+	}{
+		{b.Doubles, "2B"}, {b.Triples, "3B"}, {b.HRs, "HR"},
+		{b.Walks, "BB"}, {b.HitByPitch, "HBP"}, {b.StrikeOuts, "K"},
+	} {
+		switch {
+		case x.n == 1:
+			line += " " + x.name
+		case x.n > 1:
+			line += fmt.Sprintf(" %d%s", x.n, x.name)
+		}
+	}
+	return line
+}
+
+func formatSeasonLine(b *stats.Batting) string {
+	var avg, obp, slg float64
+	if b.AB > 0 {
+		avg = float64(b.Hits) / float64(b.AB)
+		slg = float64(b.Singles+2*b.Doubles+3*b.Triples+4*b.HRs) / float64(b.AB)
+	}
+	if n := b.AB + b.Walks + b.HitByPitch + b.SacrificeFlys; n > 0 {
+		obp = float64(b.Hits+b.Walks+b.HitByPitch) / float64(n)
+	}
+	return fmt.Sprintf("%s/%s/%s %d PA", formatAverage(avg), formatAverage(obp), formatAverage(slg), b.PA)
+}
+
+// formatAverage formats an average the way it's usually written, e.g. .333
+func formatAverage(x float64) string {
+	return strings.TrimPrefix(fmt.Sprintf("%.3f", x), "0")
+}
+
+// loadSeason reads the stats of the other games in the directory of
+// gamePath for the season lines.
+func (ui *UI) loadSeason(gamePath string) {
+	games, _ := game.ReadGamesDir(filepath.Dir(gamePath))
+	season := stats.NewGameStats(ui.RE)
+	for _, g := range games {
+		if g == nil || g.File == nil || filepath.Base(g.File.Path) == filepath.Base(gamePath) {
+			continue
+		}
+		_ = season.Read(g)
+	}
+	ui.app.QueueUpdateDraw(func() {
+		if ui.path == gamePath {
+			ui.season = season
+			ui.showSituation()
+		}
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/slshen/paperscore/pkg/boxscore"
 	"github.com/slshen/paperscore/pkg/game"
 	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/slshen/paperscore/pkg/scorebook"
 	"github.com/slshen/paperscore/pkg/stats"
 )
 
@@ -40,12 +41,26 @@ type UI struct {
 	lastUpdate        time.Time
 	focusOrder        []tview.Primitive
 	status            *tview.TextView
+	help              *tview.TextView
 	dialog            tview.Primitive
 	modified          bool
+	scorer            *Scorer
+	scoringPlays      *LinedTextArea
+	situation         *tview.TextView
+	game              *game.Game
+	season            *stats.GameStats
+	errorText         string
+	history           *History
+	lastEdit          time.Time
 }
 
 var errorColor = tcell.ColorBlue
 
+const (
+	helpText        = "Quit:^C Save:^S Choose:^L Swap:^R Box:^Z Book:F2 Undo:^_ Redo:^Y Pitches:^G Errors:^T/^O"
+	pitchesHelpText = "Pitch:BCSFXH Play:Enter Undo:Bksp Exit:^G"
+)
+
 func New() *UI {
 	ui := &UI{
 		Logger:       log.New(io.Discard, "", 0),
@@ -57,11 +72,29 @@ func New() *UI {
 		box:          tview.NewTextView(),
 		messages:     tview.NewTextView().SetDynamicColors(true),
 		status:       tview.NewTextView().SetTextAlign(tview.AlignRight),
+		help:         tview.NewTextView().SetText(helpText),
+		situation:    tview.NewTextView(),
+		history:      NewHistory(),
 	}
-	for _, box := range []any{ui.properties, ui.box, ui.visitorPlays, ui.homePlays, ui.messages} {
+	for _, box := range []any{ui.properties, ui.box, ui.visitorPlays, ui.homePlays, ui.situation, ui.messages} {
 		box.(interface{ SetBorder(bool) *tview.Box }).SetBorder(true)
 	}
 	ui.focusOrder = []tview.Primitive{ui.properties, ui.visitorPlays, ui.homePlays}
+	ui.visitorPlays.Plays = true
+	ui.homePlays.Plays = true
+	for _, area := range []*LinedTextArea{ui.properties, ui.visitorPlays, ui.homePlays} {
+		area.SetMovedFunc(func() {
+			ui.showCursorError()
+			ui.showSituation()
+		})
+		area.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+			if action == tview.MouseMove {
+				_, y := event.Position()
+				ui.showLineError(area, area.GetLineAt(y))
+			}
+			return action, event
+		})
+	}
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
 	flex.AddItem(tview.NewFlex().
 		AddItem(ui.properties, 0, 1, true).
@@ -69,11 +102,12 @@ func New() *UI {
 		7, 0, true)
 	flex.AddItem(tview.NewFlex().
 		AddItem(ui.visitorPlays, 0, 1, false).
-		AddItem(ui.homePlays, 0, 1, false),
+		AddItem(ui.homePlays, 0, 1, false).
+		AddItem(ui.situation, 36, 0, false),
 		0, 1, false).
 		AddItem(ui.messages, 6, 0, false).
 		AddItem(tview.NewFlex().
-			AddItem(tview.NewTextView().SetText("Quit:^C Save:^S Choose:^L Swap:^R Box:^Z"), 0, 4, false).
+			AddItem(ui.help, 0, 4, false).
 			AddItem(ui.status, 0, 1, false),
 			1, 0, false)
 	ui.root.AddAndSwitchToPage("main", flex, true)
@@ -124,29 +158,41 @@ func (ui *UI) update() {
 		if gm != nil {
 			ui.homePlays.SetTitle(gm.Home.Name)
 			ui.visitorPlays.SetTitle(gm.Visitor.Name)
+			ui.game = gm
+			ui.showSituation()
 		}
 		ui.properties.ClearColors()
 		ui.homePlays.ClearColors()
 		ui.visitorPlays.ClearColors()
 		for _, err := range allErrors(err) {
 			if gerr, ok := err.(interface{ Position() gamefile.Position }); ok {
-				line := gerr.Position().Line - 1
-				switch {
-				case line < ui.visitorPlaysStart:
-					ui.Logger.Println("highlighting properties line ", line)
-					ui.properties.LineColors[line] = &errorColor
-				case line < ui.homePlaysStart:
-					ui.Logger.Println("highlighting visitor plays line ", line-ui.visitorPlaysStart)
-					ui.visitorPlays.LineColors[line-ui.visitorPlaysStart] = &errorColor
-				default:
-					ui.Logger.Println("highlighting home plays line ", line-ui.homePlaysStart)
-					ui.homePlays.LineColors[line-ui.homePlaysStart] = &errorColor
+				area, line := ui.getAreaLine(gerr.Position().Line - 1)
+				ui.Logger.Println("highlighting line ", line)
+				area.LineColors[line] = &errorColor
+				if area.LineMessages[line] != "" {
+					area.LineMessages[line] += "\n"
 				}
+				area.LineMessages[line] += err.Error()
 			}
 		}
+		ui.errorText = msg
+		ui.showCursorError()
 	})
 }
 
+// getAreaLine returns the text area and the line in it of a line (from 0)
+// of the game file.
+func (ui *UI) getAreaLine(line int) (*LinedTextArea, int) {
+	switch {
+	case line < ui.visitorPlaysStart:
+		return ui.properties, line
+	case line < ui.homePlaysStart:
+		return ui.visitorPlays, line - ui.visitorPlaysStart
+	default:
+		return ui.homePlays, line - ui.homePlaysStart
+	}
+}
+
 func allErrors(err error) []error {
 	if err == nil {
 		return nil
@@ -158,26 +204,34 @@ func allErrors(err error) []error {
 }
 
 func (ui *UI) getGameText() string {
+	var text string
+	text, ui.visitorPlaysStart, ui.homePlaysStart = buildGameText(ui.properties.GetText(),
+		ui.visitorPlays.GetText(), ui.homePlays.GetText())
+	ui.lastUpdate = time.Now()
+	ui.Logger.Println("got game text at ", ui.lastUpdate)
+	return text
+}
+
+// buildGameText returns the game file text and the line numbers (from 0)
+// where the visitor and home plays start.
+func buildGameText(properties, visitorPlays, homePlays string) (string, int, int) {
 	var buf bytes.Buffer
-	fmt.Fprint(&buf, ui.properties.GetText())
+	fmt.Fprint(&buf, properties)
 	if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
 		fmt.Fprintln(&buf)
 	}
 	fmt.Fprintln(&buf, "---")
 	fmt.Fprintln(&buf, "visitorplays")
-	ui.visitorPlaysStart = lineCount(buf.String())
-	visitorPlaysText := ui.visitorPlays.GetText()
-	fmt.Fprint(&buf, visitorPlaysText)
-	ui.homePlaysStart = ui.visitorPlaysStart + 1 + lineCount(visitorPlaysText)
+	visitorPlaysStart := lineCount(buf.String())
+	fmt.Fprint(&buf, visitorPlays)
+	homePlaysStart := visitorPlaysStart + 1 + lineCount(visitorPlays)
 	if buf.Bytes()[buf.Len()-1] != '\n' {
 		fmt.Fprintln(&buf)
-		ui.homePlaysStart++
+		homePlaysStart++
 	}
 	fmt.Fprintln(&buf, "homeplays")
-	fmt.Fprintln(&buf, ui.homePlays.GetText())
-	ui.lastUpdate = time.Now()
-	ui.Logger.Println("got game text at ", ui.lastUpdate)
-	return buf.String()
+	fmt.Fprintln(&buf, homePlays)
+	return buf.String(), visitorPlaysStart, homePlaysStart
 }
 
 func lineCount(s string) (count int) {
@@ -190,6 +244,14 @@ func lineCount(s string) (count int) {
 }
 
 func (ui *UI) parseGame(gamePath string) {
+	if gamePath != ui.path {
+		ui.history.Reset()
+		ui.stopScoring()
+		ui.game = nil
+		ui.season = nil
+		ui.situation.SetText("")
+		go ui.loadSeason(gamePath)
+	}
 	ui.path = gamePath
 	ui.status.SetText(path.Base(ui.path))
 	ui.box.SetText("")
@@ -210,6 +272,22 @@ func (ui *UI) parseGame(gamePath string) {
 		defer f.Close()
 		r = f
 	}
+	ui.loadGameText(r)
+	// fake a key press so update cycle runs
+	ui.lastKey = time.Now()
+	ui.modified = false
+	if text, saved := readJournal(ui.path); text != "" {
+		if text == ui.getSnapshotText() {
+			removeJournal(ui.path)
+		} else {
+			ui.offerRecovery(text, saved)
+		}
+	}
+}
+
+// loadGameText puts the properties and plays of a game file into the
+// editing areas.
+func (ui *UI) loadGameText(r io.Reader) {
 	scanner := bufio.NewScanner(r)
 	state := "props"
 	var (
@@ -252,9 +330,6 @@ func (ui *UI) parseGame(gamePath string) {
 	if targetPlays != nil {
 		targetPlays.Replace(0, targetPlays.GetTextLength(), buf.String())
 	}
-	// fake a key press so update cycle runs
-	ui.lastKey = time.Now()
-	ui.modified = false
 }
 
 func (ui *UI) chooseFile() {
@@ -271,6 +346,8 @@ func (ui *UI) chooseFile() {
 }
 
 func (ui *UI) swapHomeAndAway() {
+	ui.stopScoring()
+	ui.history.Save(ui.getSnapshot())
 	var propertiesText strings.Builder
 	for _, line := range strings.Split(ui.properties.GetText(), "\n") {
 		if strings.HasPrefix(line, "home") && len(line) > 4 {
@@ -291,6 +368,11 @@ func (ui *UI) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 	if ui.dialog != nil {
 		return event
 	}
+	if ui.scorer != nil {
+		if event = ui.handleScoringKey(event); event == nil {
+			return nil
+		}
+	}
 	var focusInc int
 	switch event.Key() {
 	case tcell.KeyCtrlQ:
@@ -313,6 +395,24 @@ func (ui *UI) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 	case tcell.KeyCtrlZ:
 		ui.showBox()
 		return nil
+	case tcell.KeyF2:
+		ui.showScorebook()
+		return nil
+	case tcell.KeyCtrlG:
+		ui.startScoring()
+		return nil
+	case tcell.KeyCtrlUnderscore:
+		ui.undo()
+		return nil
+	case tcell.KeyCtrlY:
+		ui.redo()
+		return nil
+	case tcell.KeyCtrlT:
+		ui.gotoError(1)
+		return nil
+	case tcell.KeyCtrlO:
+		ui.gotoError(-1)
+		return nil
 	case tcell.KeyCtrlP:
 		return tcell.NewEventKey(tcell.KeyUp, 0, 0)
 	case tcell.KeyCtrlN:
@@ -337,10 +437,12 @@ func (ui *UI) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 		case ui.homePlays.HasFocus():
 			fallthrough
 		case ui.visitorPlays.HasFocus():
-			if !ui.modified {
-				ui.modified = true
-				ui.status.SetText(ui.status.GetText(false) + "*")
+			if event.When().Sub(ui.lastEdit) > time.Second {
+				// a pause in typing starts a new change to undo
+				ui.history.Save(ui.getSnapshot())
 			}
+			ui.lastEdit = event.When()
+			ui.setModified()
 			ui.lastKey = event.When()
 			ui.Logger.Println("got key at ", ui.lastKey)
 		}
@@ -363,6 +465,13 @@ func (ui *UI) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+func (ui *UI) setModified() {
+	if !ui.modified {
+		ui.modified = true
+		ui.status.SetText(ui.status.GetText(false) + "*")
+	}
+}
+
 func (ui *UI) newGame(gamePath string) {
 	file, _ := gamefile.ParseFile(gamePath)
 	if file != nil {
@@ -418,6 +527,8 @@ func (ui *UI) save() {
 			if err := os.Rename(f.Name(), ui.path); err != nil {
 				ui.messages.SetText(fmt.Sprintf("could not save %s [yellow:red]%s", ui.path, err.Error()))
 			} else {
+				removeJournal(originalPath)
+				removeJournal(ui.path)
 				ui.parseGame(ui.path)
 				if canonName != "" {
 					_ = os.Remove(originalPath)
@@ -476,6 +587,22 @@ func (ui *UI) showBox() {
 	box.IncludePlays = true
 	var s strings.Builder
 	_ = box.Render(&s)
+	ui.showTextDialog(tview.NewTextView().SetText(s.String()))
+}
+
+// showScorebook shows the game drawn as a scorebook grid.
+func (ui *UI) showScorebook() {
+	g, err := ui.parseGameText(ui.getGameText())
+	if err != nil {
+		ui.messages.SetText(fmt.Sprintf("game is invalid: %s", err.Error()))
+		return
+	}
+	var s strings.Builder
+	_ = scorebook.New(g).WriteText(&s)
+	ui.showTextDialog(tview.NewTextView().SetWrap(false).SetText(s.String()))
+}
+
+func (ui *UI) showTextDialog(view *tview.TextView) {
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
 	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyCtrlC {
@@ -484,7 +611,6 @@ func (ui *UI) showBox() {
 		}
 		return event
 	})
-	view := tview.NewTextView().SetText(s.String())
 	view.SetBorder(true)
 	flex.AddItem(view, 0, 1, true).
 		AddItem(tview.NewTextView().SetText("Close:^C"), 1, 0, false)
@@ -510,6 +636,9 @@ func (ui *UI) Run(path string) error {
 	ticker := time.NewTicker(250 * time.Millisecond)
 	done := make(chan bool)
 	go ui.backgroundUpdate(ticker, done)
+	autosaveTicker := time.NewTicker(autosaveInterval)
+	autosaveDone := make(chan bool)
+	go ui.autosave(autosaveTicker, autosaveDone)
 	if path == "" {
 		path = "."
 	}
@@ -536,5 +665,13 @@ func (ui *UI) Run(path string) error {
 	err = ui.app.Run()
 	ticker.Stop()
 	done <- true
+	autosaveTicker.Stop()
+	autosaveDone <- true
+	if ui.modified {
+		// don't lose the changes since the last autosave
+		if err := writeJournal(ui.path, ui.getSnapshotText()); err != nil {
+			ui.Logger.Println("cannot write journal: ", err)
+		}
+	}
 	return err
 }
@@ -0,0 +1,403 @@
+package web
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/stats"
+	"github.com/slshen/paperscore/pkg/tournament"
+)
+
+// StateData is a game.State without the references to other states and
+// teams.
+type StateData struct {
+	Line           int              `json:"line"`
+	Inning         int              `json:"inning"`
+	Half           game.Half        `json:"half"`
+	Batting        string           `json:"batting"`
+	Fielding       string           `json:"fielding"`
+	Outs           int              `json:"outs"`
+	Score          int              `json:"score"`
+	Pitcher        game.PlayerID    `json:"pitcher"`
+	PA             int              `json:"pa"`
+	Batter         game.PlayerID    `json:"batter"`
+	Pitches        game.Pitches     `json:"pitches"`
+	PlayCode       string           `json:"playCode"`
+	PlayType       string           `json:"playType"`
+	Advances       []*AdvanceData   `json:"advances"`
+	Runners        [3]game.PlayerID `json:"runners"`
+	ScoringRunners []game.PlayerID  `json:"scoringRunners"`
+	Complete       bool             `json:"complete"`
+	Comment        string           `json:"comment,omitempty"`
+}
+
+type AdvanceData struct {
+	Code   string        `json:"code"`
+	Runner game.PlayerID `json:"runner"`
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Out    bool          `json:"out"`
+}
+
+// Filter selects games by team, tournament and date.
+type Filter struct {
+	// Team matches the name or ID of either team
+	Team       string
+	Tournament string
+	From, To   time.Time
+}
+
+// handle registers a handler that's only called if the client doesn't
+// already have the response.  Handlers are called one at a time since the
+// stats of the games add players to their teams.
+func (s *Server) handle(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		etag, err := s.getETag()
+		if err != nil {
+			s.writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// getETag returns an ETag made from the number of files in the directory
+// of games and when the last one was modified.
+func (s *Server) getETag() (string, error) {
+	var (
+		count  int
+		latest time.Time
+	)
+	err := filepath.WalkDir(s.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		count++
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return fmt.Sprintf(`"%d-%d"`, count, latest.UnixNano()), err
+}
+
+// load returns the games in the directory, continuations stitched onto
+// the games they continue, reading them again if any files have changed.
+// Games that can't be read are left out.  s.mu must be held.
+func (s *Server) load() ([]*game.Game, error) {
+	etag, err := s.getETag()
+	if err != nil {
+		return nil, err
+	}
+	if etag == s.etag {
+		return s.games, nil
+	}
+	paths, err := listGameFiles(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+	for i, path := range paths {
+		paths[i] = filepath.Join(s.Dir, path)
+	}
+	games, err := game.ReadGameFiles(paths)
+	if err != nil {
+		s.Logger.Printf("some games could not be read: %s", err)
+	}
+	s.games = nil
+	for _, g := range games {
+		if g != nil {
+			s.games = append(s.games, g)
+		}
+	}
+	sort.SliceStable(s.games, func(i, j int) bool {
+		return s.games[i].GetDate().Before(s.games[j].GetDate())
+	})
+	s.etag = etag
+	return s.games, nil
+}
+
+// getGamePath returns the path in the API of the file of g.
+func (s *Server) getGamePath(g *game.Game) string {
+	rel, err := filepath.Rel(s.Dir, g.File.Path)
+	if err != nil {
+		return g.File.Path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "1/2/2006", "1/2/06"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse date %s, use YYYY-MM-DD", s)
+}
+
+// GetFilter returns the filter from the team, tournament, from and to
+// query parameters of r.
+func GetFilter(r *http.Request) (*Filter, error) {
+	q := r.URL.Query()
+	f := &Filter{
+		Team:       q.Get("team"),
+		Tournament: q.Get("tournament"),
+	}
+	var err error
+	if from := q.Get("from"); from != "" {
+		if f.From, err = parseDate(from); err != nil {
+			return nil, err
+		}
+	}
+	if to := q.Get("to"); to != "" {
+		if f.To, err = parseDate(to); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// IsEmpty returns true if the filter matches every game.
+func (f *Filter) IsEmpty() bool {
+	return *f == Filter{}
+}
+
+func (f *Filter) Match(g *game.Game) bool {
+	if f.Team != "" {
+		team := strings.ToLower(f.Team)
+		match := func(t *game.Team) bool {
+			return strings.Contains(strings.ToLower(t.Name), team) || strings.ToLower(string(t.ID)) == team
+		}
+		if !match(g.Visitor) && !match(g.Home) {
+			return false
+		}
+	}
+	if f.Tournament != "" &&
+		!strings.Contains(strings.ToLower(g.GetTournament()), strings.ToLower(f.Tournament)) {
+		return false
+	}
+	date := g.GetDate()
+	if !f.From.IsZero() && date.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && date.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// queryGames returns the games in the dir parameter of r, or all games,
+// that match the filter in r.  It writes an error response and returns
+// false if the query is invalid.
+func (s *Server) queryGames(w http.ResponseWriter, r *http.Request) ([]*game.Game, bool) {
+	f, err := GetFilter(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	dir := r.URL.Query().Get("dir")
+	if dir != "" && !filepath.IsLocal(dir) {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("%s is not in the game directory", dir))
+		return nil, false
+	}
+	dir = filepath.ToSlash(filepath.Clean(dir))
+	games, err := s.load()
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	var res []*game.Game
+	for _, g := range games {
+		if dir != "." && !strings.HasPrefix(s.getGamePath(g), dir+"/") {
+			continue
+		}
+		if f.Match(g) {
+			res = append(res, g)
+		}
+	}
+	return res, true
+}
+
+func newStateData(state *game.State) *StateData {
+	sd := &StateData{
+		Line:           state.Pos.Line,
+		Inning:         state.InningNumber,
+		Half:           state.Half,
+		Batting:        state.BattingTeam.Name,
+		Fielding:       state.FieldingTeam.Name,
+		Outs:           state.Outs,
+		Score:          state.Score,
+		Pitcher:        state.Pitcher,
+		PA:             state.PlateAppearance.Number,
+		Batter:         state.Batter,
+		Pitches:        state.Pitches,
+		PlayCode:       state.PlayCode,
+		PlayType:       state.Play.Type.String(),
+		Advances:       []*AdvanceData{},
+		Runners:        state.Runners,
+		ScoringRunners: state.ScoringRunners,
+		Complete:       state.Complete,
+		Comment:        state.Comment,
+	}
+	for _, adv := range state.Advances {
+		sd.Advances = append(sd.Advances, &AdvanceData{
+			Code:   adv.Code,
+			Runner: adv.Runner,
+			From:   adv.From,
+			To:     adv.To,
+			Out:    adv.Out,
+		})
+	}
+	if sd.ScoringRunners == nil {
+		sd.ScoringRunners = []game.PlayerID{}
+	}
+	return sd
+}
+
+func (s *Server) handleStates(w http.ResponseWriter, r *http.Request) {
+	g := s.readGame(w, r)
+	if g == nil {
+		return
+	}
+	states := []*StateData{}
+	for _, state := range g.GetStates() {
+		states = append(states, newStateData(state))
+	}
+	s.writeJSON(w, states)
+}
+
+// getRunExpectancy returns the configured RE or the observed RE of games.
+func (s *Server) getRunExpectancy(games []*game.Game) (stats.RunExpectancy, error) {
+	if s.RE != nil {
+		return s.RE, nil
+	}
+	observed := &stats.ObservedRunExpectancy{}
+	for _, g := range games {
+		if err := observed.Read(g); err != nil {
+			return nil, err
+		}
+	}
+	return observed, nil
+}
+
+// handleStats returns the batting or pitching stats of the games queried.
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	games, ok := s.queryGames(w, r)
+	if !ok {
+		return
+	}
+	re, err := s.getRunExpectancy(games)
+	if err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	gs := stats.NewGameStats(re)
+	for _, g := range games {
+		if err := gs.Read(g); err != nil {
+			s.writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+	switch r.URL.Query().Get("type") {
+	case "", "batting":
+		s.writeJSON(w, gs.GetBattingData())
+	case "pitching":
+		s.writeJSON(w, gs.GetPitchingData())
+	default:
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("unknown stats type %s", r.URL.Query().Get("type")))
+	}
+}
+
+func (s *Server) handleRE(w http.ResponseWriter, r *http.Request) {
+	games, ok := s.queryGames(w, r)
+	if !ok {
+		return
+	}
+	re, err := s.getRunExpectancy(games)
+	if err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	s.writeJSON(w, stats.GetRunExpectancyData(re))
+}
+
+func (s *Server) handleTournaments(w http.ResponseWriter, r *http.Request) {
+	games, ok := s.queryGames(w, r)
+	if !ok {
+		return
+	}
+	type tournamentData struct {
+		Name       string   `json:"name"`
+		Tournament string   `json:"tournament"`
+		Date       string   `json:"date"`
+		Games      []string `json:"games"`
+	}
+	res := []*tournamentData{}
+	for _, gr := range tournament.GroupByTournament(games) {
+		td := &tournamentData{
+			Name:       gr.Name,
+			Tournament: gr.Tournament,
+			Date:       gr.Date.Format("2006-01-02"),
+		}
+		for _, g := range gr.Games {
+			td.Games = append(td.Games, s.getGamePath(g))
+		}
+		res = append(res, td)
+	}
+	s.writeJSON(w, res)
+}
+
+// handleTournamentReport returns the report of the tournament named by the
+// name parameter for the team named by the us parameter.
+func (s *Server) handleTournamentReport(w http.ResponseWriter, r *http.Request) {
+	games, ok := s.queryGames(w, r)
+	if !ok {
+		return
+	}
+	name, us := r.URL.Query().Get("name"), r.URL.Query().Get("us")
+	if name == "" || us == "" {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("name and us are required"))
+		return
+	}
+	for _, gr := range tournament.GroupByTournament(games) {
+		if gr.Name != name {
+			continue
+		}
+		re, err := s.getRunExpectancy(gr.Games)
+		if err != nil {
+			s.writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		rep, err := tournament.NewReport(us, re, gr)
+		if err != nil {
+			s.writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		s.writeJSON(w, map[string]any{
+			"batting":  rep.GetBattingData(),
+			"pitching": rep.GetPitchingData(),
+			"plays":    rep.GetBestAndWorstRE24(15),
+		})
+		return
+	}
+	s.writeError(w, http.StatusNotFound, fmt.Errorf("no tournament %s", name))
+}
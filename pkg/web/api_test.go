@@ -0,0 +1,153 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeGame(t *testing.T, path, date, visitor string) {
+	text := `date: ` + date + `
+game: 1
+visitor: ` + visitor + `
+home: b
+tournament: Memorial Day
+---
+visitorplays
+pitching 9
+1 1 BX H/F7
+2 2 CSS K
+3 3 CSS K
+4 4 CSS K
+homeplays
+pitching 8
+1 1 CSS K
+`
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(text), 0644))
+}
+
+func get(s *Server, url string, header ...string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", url, nil)
+	if len(header) == 2 {
+		r.Header.Set(header[0], header[1])
+	}
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func TestAPI(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	writeGame(t, filepath.Join(dir, "t1", "20220521-1.gm"), "5/21/22", "a")
+	writeGame(t, filepath.Join(dir, "t2", "20220522-1.gm"), "5/22/22", "c")
+	s := NewServer(dir, nil)
+
+	var games []*GameSummary
+	w := get(s, "/api/games")
+	assert.Equal(http.StatusOK, w.Code)
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &games))
+	assert.Len(games, 2)
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(etag)
+	assert.Equal(http.StatusNotModified, get(s, "/api/games", "If-None-Match", etag).Code)
+
+	for _, q := range []string{"team=c", "from=2022-05-22", "to=5/21/22&tournament=memorial"} {
+		games = nil
+		assert.NoError(json.Unmarshal(get(s, "/api/games?"+q).Body.Bytes(), &games))
+		assert.Len(games, 1, q)
+	}
+	assert.Equal(http.StatusBadRequest, get(s, "/api/games?from=May").Code)
+
+	var states []*StateData
+	assert.NoError(json.Unmarshal(get(s, "/api/states?path=t1/20220521-1.gm").Body.Bytes(), &states))
+	if assert.Len(states, 5) {
+		assert.Equal("H/F7", states[0].PlayCode)
+		assert.Equal(1, states[0].Score)
+		assert.Equal(1, states[1].Outs)
+	}
+	assert.Equal(http.StatusNotFound, get(s, "/api/states?path=t1/nope.gm").Code)
+
+	var stats struct {
+		RowData []map[string]any `json:"rowData"`
+	}
+	assert.NoError(json.Unmarshal(get(s, "/api/stats?dir=t1&team=a").Body.Bytes(), &stats))
+	if assert.NotEmpty(stats.RowData) {
+		// RE24 uses the RE observed from the games
+		assert.NotZero(stats.RowData[0]["RE24"])
+	}
+	assert.Equal(http.StatusBadRequest, get(s, "/api/stats?type=fielding").Code)
+	assert.Equal(http.StatusBadRequest, get(s, "/api/stats?dir=../t1").Code)
+	var re struct {
+		RowData []map[string]any `json:"rowData"`
+	}
+	assert.NoError(json.Unmarshal(get(s, "/api/re?dir=t1").Body.Bytes(), &re))
+	if assert.NotEmpty(re.RowData) {
+		assert.Equal(0.5, re.RowData[0]["0Out"])
+	}
+	var tournaments []struct {
+		Name  string
+		Games []string
+	}
+	assert.NoError(json.Unmarshal(get(s, "/api/tournaments").Body.Bytes(), &tournaments))
+	if assert.Len(tournaments, 1) {
+		assert.Equal([]string{"t1/20220521-1.gm", "t2/20220522-1.gm"}, tournaments[0].Games)
+	}
+	assert.Equal(http.StatusNotFound, get(s, "/api/tournaments/report?name=nope&us=a").Code)
+
+	// a changed file changes the ETag and the games
+	writeGame(t, filepath.Join(dir, "t2", "20220522-1.gm"), "5/22/22", "d")
+	future := time.Now().Add(time.Minute)
+	assert.NoError(os.Chtimes(filepath.Join(dir, "t2", "20220522-1.gm"), future, future))
+	w = get(s, "/api/games?team=d", "If-None-Match", etag)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.NotEqual(etag, w.Header().Get("ETag"))
+	games = nil
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &games))
+	assert.Len(games, 1)
+
+	s.ReadOnly = true
+	w = request(s, "PUT", "/api/game", `{"path":"t1/20220521-1.gm"}`)
+	assert.Equal(http.StatusForbidden, w.Code)
+}
+
+func TestAPIConcurrent(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	writeGame(t, filepath.Join(dir, "t1", "20220521-1.gm"), "5/21/22", "a")
+	s := NewServer(dir, nil)
+	var (
+		wg    sync.WaitGroup
+		start = make(chan bool)
+		codes = make([]int, 8)
+	)
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for _, url := range []string{"/api/stats", "/api/stats?type=pitching",
+				"/api/box?path=t1/20220521-1.gm",
+				"/api/tournaments/report?name=05%2F21%2F2022+Memorial+Day&us=a"} {
+				if w := get(s, url); w.Code != http.StatusOK {
+					codes[i] = w.Code
+					return
+				}
+			}
+			codes[i] = http.StatusOK
+		}()
+	}
+	close(start)
+	wg.Wait()
+	for _, code := range codes {
+		assert.Equal(http.StatusOK, code)
+	}
+}
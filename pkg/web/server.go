@@ -0,0 +1,379 @@
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/slshen/paperscore/pkg/boxscore"
+	"github.com/slshen/paperscore/pkg/dataframe"
+	"github.com/slshen/paperscore/pkg/game"
+	"github.com/slshen/paperscore/pkg/gamefile"
+	"github.com/slshen/paperscore/pkg/playbyplay"
+	"github.com/slshen/paperscore/pkg/stats"
+)
+
+//go:embed static
+var staticFS embed.FS
+
+// Server serves the games in a directory to the browser app, as a JSON
+// API under /api and the app itself at /.
+type Server struct {
+	// Dir is the directory of games, all paths in the API are relative
+	// to it
+	Dir string
+	// RE is the run expectancy, or nil to use the observed run
+	// expectancy of the games queried
+	RE stats.RunExpectancy
+	// ReadOnly is true if games can't be saved
+	ReadOnly bool
+	Logger   *log.Logger
+
+	mux   *http.ServeMux
+	mu    sync.Mutex
+	etag  string
+	games []*game.Game
+}
+
+// GameSummary is a game in the game list.
+type GameSummary struct {
+	Path       string          `json:"path"`
+	Date       string          `json:"date"`
+	Number     string          `json:"number"`
+	Tournament string          `json:"tournament"`
+	Visitor    string          `json:"visitor"`
+	Home       string          `json:"home"`
+	Final      game.Score      `json:"final"`
+	Ending     game.GameEnding `json:"ending,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// Problem is an error in a game file, with its line and column if it
+// has them.
+type Problem struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// GameText is the text of a game file and its problems.
+type GameText struct {
+	Path     string     `json:"path"`
+	Text     string     `json:"text"`
+	Problems []*Problem `json:"problems"`
+}
+
+func NewServer(dir string, re stats.RunExpectancy) *Server {
+	s := &Server{
+		Dir:    dir,
+		RE:     re,
+		Logger: log.Default(),
+		mux:    http.NewServeMux(),
+	}
+	static, _ := fs.Sub(staticFS, "static")
+	s.mux.Handle("GET /", http.FileServerFS(static))
+	s.handle("GET /api/games", s.handleGames)
+	s.mux.HandleFunc("GET /api/game", s.handleGetGame)
+	s.mux.HandleFunc("PUT /api/game", s.handlePutGame)
+	s.mux.HandleFunc("POST /api/validate", s.handleValidate)
+	s.handle("GET /api/box", s.handleBox)
+	s.handle("GET /api/plays", s.handlePlays)
+	s.handle("GET /api/states", s.handleStates)
+	s.handle("GET /api/stats", s.handleStats)
+	s.handle("GET /api/re", s.handleRE)
+	s.handle("GET /api/tournaments", s.handleTournaments)
+	s.handle("GET /api/tournaments/report", s.handleTournamentReport)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// getPath returns the file path for a path in the API, which must be in
+// the directory of games.
+func (s *Server) getPath(p string) (string, error) {
+	if p == "" {
+		return s.Dir, nil
+	}
+	if !filepath.IsLocal(p) {
+		return "", fmt.Errorf("%s is not in the game directory", p)
+	}
+	return filepath.Join(s.Dir, p), nil
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.Logger.Printf("cannot write response: %s", err)
+	}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// listGameFiles returns the paths of the game files in dir and its
+// subdirectories, relative to dir.
+func listGameFiles(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".gm") {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(paths)
+	return paths, err
+}
+
+// handleGames returns every game file, including the ones with errors
+// unless the games are filtered.
+func (s *Server) handleGames(w http.ResponseWriter, r *http.Request) {
+	f, err := GetFilter(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	paths, err := listGameFiles(s.Dir)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	summaries := []*GameSummary{}
+	for _, path := range paths {
+		summary := &GameSummary{Path: path}
+		g, err := game.ReadGameFile(filepath.Join(s.Dir, path))
+		if err != nil {
+			summary.Error = err.Error()
+		}
+		if !f.IsEmpty() && (g == nil || !f.Match(g)) {
+			continue
+		}
+		if g != nil {
+			summary.Date = g.Date
+			summary.Number = g.Number
+			summary.Tournament = g.Tournament
+			summary.Visitor = g.Visitor.Name
+			summary.Home = g.Home.Name
+			summary.Final = g.Final
+			summary.Ending = g.Ending
+		}
+		summaries = append(summaries, summary)
+	}
+	s.writeJSON(w, summaries)
+}
+
+func (s *Server) handleGetGame(w http.ResponseWriter, r *http.Request) {
+	path, err := s.getPath(r.URL.Query().Get("path"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.writeError(w, http.StatusNotFound, err)
+		} else {
+			s.writeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	problems := validate(path, string(dat))
+	s.writeJSON(w, &GameText{
+		Path:     r.URL.Query().Get("path"),
+		Text:     string(dat),
+		Problems: problems,
+	})
+}
+
+// handlePutGame saves the text of a game.  If the text parses it's saved
+// in the canonical format, like the terminal UI does.
+func (s *Server) handlePutGame(w http.ResponseWriter, r *http.Request) {
+	if s.ReadOnly {
+		s.writeError(w, http.StatusForbidden, fmt.Errorf("games cannot be saved"))
+		return
+	}
+	var gt GameText
+	if err := json.NewDecoder(r.Body).Decode(&gt); err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	path, err := s.getPath(gt.Path)
+	if err != nil || !strings.HasSuffix(path, ".gm") {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("cannot save %s", gt.Path))
+		return
+	}
+	text := gt.Text
+	if gf, err := gamefile.ParseString(path, text); err == nil {
+		var b strings.Builder
+		gf.Write(&b)
+		text = b.String()
+	}
+	if err := writeFile(path, text); err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	gt.Problems = validate(path, text)
+	gt.Text = text
+	s.writeJSON(w, &gt)
+}
+
+// writeFile replaces the file at path by renaming a new file, so a failed
+// write doesn't lose the game.
+func writeFile(path, text string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+"*")
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var gt GameText
+	if err := json.NewDecoder(r.Body).Decode(&gt); err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	path, err := s.getPath(gt.Path)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	gt.Problems = validate(path, gt.Text)
+	gt.Text = ""
+	s.writeJSON(w, &gt)
+}
+
+// validate parses and runs the game in text, returning its problems.
+func validate(path, text string) []*Problem {
+	gf, err := gamefile.ParseString(path, text)
+	if err == nil {
+		_, err = game.NewGame(gf)
+	}
+	return getProblems(err)
+}
+
+func getProblems(err error) []*Problem {
+	problems := []*Problem{}
+	if err == nil {
+		return problems
+	}
+	errs := []error{err}
+	if m, ok := err.(*multierror.Error); ok && m.Len() > 0 {
+		errs = m.Errors
+	}
+	for _, err := range errs {
+		problem := &Problem{Message: err.Error()}
+		if perr, ok := err.(interface{ Position() gamefile.Position }); ok {
+			problem.Line = perr.Position().Line
+			problem.Column = perr.Position().Column
+		}
+		problems = append(problems, problem)
+	}
+	return problems
+}
+
+// readGame reads the game at the path in the request, writing an error
+// response and returning nil if it can't be read.
+func (s *Server) readGame(w http.ResponseWriter, r *http.Request) *game.Game {
+	path, err := s.getPath(r.URL.Query().Get("path"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return nil
+	}
+	g, err := game.ReadGameFile(path)
+	if g == nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.writeError(w, http.StatusNotFound, err)
+		} else {
+			s.writeError(w, http.StatusUnprocessableEntity, err)
+		}
+		return nil
+	}
+	return g
+}
+
+func (s *Server) handleBox(w http.ResponseWriter, r *http.Request) {
+	g := s.readGame(w, r)
+	if g == nil {
+		return
+	}
+	re, err := s.getRunExpectancy([]*game.Game{g})
+	if err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	box, err := boxscore.NewBoxScore(g, re)
+	if err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	box.IncludeScoringPlays = true
+	var b strings.Builder
+	if err := box.Render(&b); err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	lineup := func(l *boxscore.Lineup) map[string]*dataframe.Data {
+		return map[string]*dataframe.Data{
+			"batting":  l.PlayerTable(),
+			"pitching": l.PitchingTable(),
+		}
+	}
+	s.writeJSON(w, map[string]any{
+		"text":    b.String(),
+		"innings": box.InningScoreTable(),
+		"visitor": lineup(box.VisitorLineup),
+		"home":    lineup(box.HomeLineup),
+	})
+}
+
+func (s *Server) handlePlays(w http.ResponseWriter, r *http.Request) {
+	g := s.readGame(w, r)
+	if g == nil {
+		return
+	}
+	gen := playbyplay.Generator{Game: g}
+	var b strings.Builder
+	if err := gen.Generate(&b); err != nil {
+		s.writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	s.writeJSON(w, map[string]string{"text": b.String()})
+}
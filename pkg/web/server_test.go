@@ -0,0 +1,88 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testGame = `date: 5/21/22
+game: 1
+visitor: a
+home: b
+---
+visitorplays
+pitching 9
+1 1 BX S8/G8
+2 2 CSS K
+homeplays
+pitching 8
+1 1 CSS K
+`
+
+func request(s *Server, method, url, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(method, url, strings.NewReader(body)))
+	return w
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	assert.NoError(os.Mkdir(filepath.Join(dir, "t1"), 0755))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "t1", "20220521-1.gm"), []byte(testGame), 0644))
+	s := NewServer(dir, nil)
+
+	w := request(s, "GET", "/api/games", "")
+	assert.Equal(http.StatusOK, w.Code)
+	var games []*GameSummary
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &games))
+	if assert.Len(games, 1) {
+		assert.Equal("t1/20220521-1.gm", games[0].Path)
+		assert.Equal("a", games[0].Visitor)
+		assert.Empty(games[0].Error)
+	}
+
+	w = request(s, "GET", "/api/game?path=t1/20220521-1.gm", "")
+	var gt GameText
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &gt))
+	assert.Equal(testGame, gt.Text)
+	assert.Empty(gt.Problems)
+
+	w = request(s, "GET", "/api/game?path=../secret", "")
+	assert.Equal(http.StatusBadRequest, w.Code)
+
+	bad := strings.Replace(testGame, "CSS K", "CSS QQ", 1)
+	body, _ := json.Marshal(&GameText{Path: "t1/20220521-1.gm", Text: bad})
+	w = request(s, "POST", "/api/validate", string(body))
+	gt = GameText{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &gt))
+	if assert.Len(gt.Problems, 1) {
+		assert.Equal(9, gt.Problems[0].Line)
+		assert.Contains(gt.Problems[0].Message, "QQ")
+	}
+
+	body, _ = json.Marshal(&GameText{Path: "t1/20220521-1.gm", Text: strings.Replace(testGame, "BX S8", "BBX S8", 1)})
+	w = request(s, "PUT", "/api/game", string(body))
+	assert.Equal(http.StatusOK, w.Code)
+	dat, _ := os.ReadFile(filepath.Join(dir, "t1", "20220521-1.gm"))
+	assert.Contains(string(dat), "BBX S8")
+
+	w = request(s, "GET", "/api/box?path=t1/20220521-1.gm", "")
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), "a at b")
+
+	w = request(s, "GET", "/api/stats?dir=t1", "")
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), "rowData")
+
+	w = request(s, "GET", "/", "")
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), "paperscore")
+}